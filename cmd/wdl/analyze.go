@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/powellquiring/gowordle/gowordle"
+)
+
+// analyze replays a game and rates each guess against the strategy
+func analyze(globalConfig GlobalConfiguration, solution string, guesses []string) error {
+	if !slices.Contains(globalConfig.Answers, solution) {
+		return fmt.Errorf("solution %s is not in the answer list", solution)
+	}
+	guessWWs := make([]gowordle.WordleWord, 0, len(guesses))
+	for _, guess := range guesses {
+		guessWW, err := validGuess(globalConfig, guess)
+		if err != nil {
+			return err
+		}
+		guessWWs = append(guessWWs, guessWW)
+	}
+	analysis := gowordle.AnalyzeGame(globalConfig.Words.GuessList(), globalConfig.Words.Answers, gowordle.WordleWord([]rune(solution)), guessWWs, gowordle.WordleWord([]rune(globalConfig.FirstWord)))
+	if !globalConfig.Output.Text() {
+		for i, ga := range analysis.Guesses {
+			record := guessAnalysisRecord{i + 1, string(ga.Guess[:]), string(ga.Colors[:]), ga.Before, ga.After,
+				string(ga.Best[:]), ga.BestAfter, ga.Expected, ga.BestExpected, ga.Skill, ga.Luck, ga.SkillRating, ga.LuckRating}
+			if err := globalConfig.Output.Write(record); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	fmt.Println("solution:", solution)
+	fmt.Println("guess colors before after | best best-after | expected best-expected | skill luck | skill-rating luck-rating")
+	for _, ga := range analysis.Guesses {
+		fmt.Printf("%s %s %d %d | %s %.1f | %.3f %.3f | %+.3f %+.3f | %.0f %.0f\n",
+			string(ga.Guess[:]), gowordle.FormatFeedback(ga.Colors), ga.Before, ga.After,
+			string(ga.Best[:]), ga.BestAfter,
+			ga.Expected, ga.BestExpected,
+			ga.Skill, ga.Luck,
+			ga.SkillRating, ga.LuckRating)
+	}
+	fmt.Println("---------------------")
+	if analysis.Solved {
+		fmt.Printf("solved in %d, the strategy averages %.3f guesses\n", len(analysis.Guesses), analysis.BestExpected)
+	} else {
+		fmt.Printf("not solved after %d guesses, the strategy averages %.3f guesses\n", len(analysis.Guesses), analysis.BestExpected)
+	}
+	fmt.Printf("guesses gained by skill: %+.3f\n", analysis.Skill)
+	fmt.Printf("guesses gained by luck: %+.3f\n", analysis.Luck)
+	fmt.Printf("skill rating: %.0f luck rating: %.0f\n", analysis.SkillRating, analysis.LuckRating)
+	for i, ga := range analysis.Guesses {
+		if ga.Skill < -0.0005 {
+			fmt.Printf("guess %d %s lost %.3f guesses compared to %s\n", i+1, string(ga.Guess[:]), -ga.Skill, string(ga.Best[:]))
+		} else if ga.Skill > 0.0005 {
+			fmt.Printf("guess %d %s gained %.3f guesses compared to %s\n", i+1, string(ga.Guess[:]), ga.Skill, string(ga.Best[:]))
+		}
+	}
+	return nil
+}
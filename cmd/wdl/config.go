@@ -0,0 +1,239 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/powellquiring/gowordle/gowordle"
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+)
+
+/*
+Defaults for the flags are read from YAML config files, the user file and then the project file so the project
+wins.  An explicit flag always wins over the files:
+
+	user:    <user config dir>/wdl/config.yaml, ~/.config/wdl/config.yaml on linux
+	project: .wdl.yaml in the current directory or the closest parent directory
+	--config file is used instead of both
+
+	count: 500
+	first: salet
+	progress: true
+	dict: words/answers.txt     # relative paths are relative to the config file
+	memo: cache/memo.jsonl
+	profile: fast               # profile used when there is no --profile
+	profiles:
+	  fast:
+	    strategy: matches
+	  deep:
+	    recursive: true
+	    strategy: recursive
+	    workers: 2
+
+A profile is a named group of the same settings applied over the files.
+*/
+
+const projectConfigName = ".wdl.yaml"
+
+type Config struct {
+	Count     *int    `yaml:"count,omitempty" json:"count,omitempty"`
+	Recursive *bool   `yaml:"recursive,omitempty" json:"recursive,omitempty"`
+	Progress  *bool   `yaml:"progress,omitempty" json:"progress,omitempty"`
+	First     *string `yaml:"first,omitempty" json:"first,omitempty"`
+	Notation  *string `yaml:"notation,omitempty" json:"notation,omitempty"`
+	Output    *string `yaml:"output,omitempty" json:"output,omitempty"`
+	Dict      *string `yaml:"dict,omitempty" json:"dict,omitempty"`
+	Guesses   *string `yaml:"guesses,omitempty" json:"guesses,omitempty"`
+	History   *string `yaml:"history,omitempty" json:"history,omitempty"`
+	Memo      *string `yaml:"memo,omitempty" json:"memo,omitempty"`
+	Events    *string `yaml:"events,omitempty" json:"events,omitempty"`
+	Stats     *string `yaml:"stats,omitempty" json:"stats,omitempty"`
+	Workers   *int    `yaml:"workers,omitempty" json:"workers,omitempty"`   // sim and measure
+	Strategy  *string `yaml:"strategy,omitempty" json:"strategy,omitempty"` // measure
+	Profile   *string `yaml:"profile,omitempty" json:"profile,omitempty"`
+
+	Profiles map[string]Config `yaml:"profiles,omitempty" json:"profiles,omitempty"`
+	Files    []string          `yaml:"-" json:"files,omitempty"` // config files read, user first
+}
+
+// configPaths are the config settings that are file names
+var configPaths = []string{"Dict", "Guesses", "History", "Memo", "Stats"}
+
+// merge sets the settings in over, the profiles are merged by name
+func (c *Config) merge(over Config) {
+	value := reflect.ValueOf(c).Elem()
+	overValue := reflect.ValueOf(over)
+	for i := 0; i < value.NumField(); i++ {
+		if field := overValue.Field(i); field.Kind() == reflect.Pointer && !field.IsNil() {
+			value.Field(i).Set(field)
+		}
+	}
+	for name, profile := range over.Profiles {
+		if c.Profiles == nil {
+			c.Profiles = make(map[string]Config)
+		}
+		merged := c.Profiles[name]
+		merged.merge(profile)
+		c.Profiles[name] = merged
+	}
+	c.Files = append(c.Files, over.Files...)
+}
+
+// resolvePaths makes the relative file names relative to dir
+func (c *Config) resolvePaths(dir string) {
+	value := reflect.ValueOf(c).Elem()
+	for _, name := range configPaths {
+		field := value.FieldByName(name)
+		if field.IsNil() {
+			continue
+		}
+		path := field.Elem().String()
+		if path != "" && path != "-" && !filepath.IsAbs(path) && !strings.HasPrefix(path, gowordle.BuiltinPrefix) {
+			path = filepath.Join(dir, path)
+			field.Set(reflect.ValueOf(&path))
+		}
+	}
+	for name, profile := range c.Profiles {
+		profile.resolvePaths(dir)
+		c.Profiles[name] = profile
+	}
+}
+
+// readConfig reads one config file
+func readConfig(path string) (Config, error) {
+	ret := Config{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ret, err
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&ret); err != nil && !errors.Is(err, io.EOF) {
+		return ret, fmt.Errorf("%s: %w", path, err)
+	}
+	ret.resolvePaths(filepath.Dir(path))
+	ret.Files = []string{path}
+	return ret, nil
+}
+
+// configFiles are the user and project config files that exist, or just the file provided
+func configFiles(path string) ([]string, error) {
+	if path != "" {
+		return []string{path}, nil
+	}
+	ret := []string{}
+	if dir, err := os.UserConfigDir(); err == nil {
+		user := filepath.Join(dir, "wdl", "config.yaml")
+		if _, err := os.Stat(user); err == nil {
+			ret = append(ret, user)
+		}
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		project := filepath.Join(dir, projectConfigName)
+		if _, err := os.Stat(project); err == nil {
+			ret = append(ret, project)
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return ret, nil
+}
+
+// loadConfig merges the config files and then the profile, the profile in the files is used if profile is empty
+func loadConfig(path string, profile string) (Config, error) {
+	ret := Config{}
+	files, err := configFiles(path)
+	if err != nil {
+		return ret, err
+	}
+	for _, file := range files {
+		config, err := readConfig(file)
+		if err != nil {
+			return ret, err
+		}
+		ret.merge(config)
+	}
+	if profile == "" && ret.Profile != nil {
+		profile = *ret.Profile
+	}
+	if profile != "" {
+		settings, ok := ret.Profiles[profile]
+		if !ok {
+			return ret, fmt.Errorf("no profile %s in %v", profile, ret.Files)
+		}
+		ret.merge(settings)
+		ret.Profile = &profile
+	}
+	return ret, nil
+}
+
+// configure sets dest to the config value unless the flag was provided
+func configure[T any](cmd *cli.Command, name string, value *T, dest *T) {
+	if value != nil && !cmd.IsSet(name) {
+		*dest = *value
+	}
+}
+
+// nonEmpty is nil for an empty string so it is left out
+func nonEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// configShow prints the settings in use after the config files, the profile and the flags
+func configShow(globalConfig GlobalConfiguration, flags GlobalFlags, notation string) error {
+	shown := Config{
+		Count:     &flags.Count,
+		Recursive: &flags.Recursive,
+		Progress:  &flags.Progress,
+		First:     &globalConfig.FirstWord,
+		Notation:  nonEmpty(notation),
+		Output:    &flags.Output,
+		Dict:      nonEmpty(flags.Dict),
+		Guesses:   nonEmpty(flags.Guesses),
+		History:   nonEmpty(flags.History),
+		Memo:      nonEmpty(flags.Memo),
+		Events:    nonEmpty(flags.Events),
+		Stats:     nonEmpty(flags.Stats),
+		Workers:   flags.config.Workers,
+		Strategy:  flags.config.Strategy,
+		Profile:   flags.config.Profile,
+		Profiles:  flags.config.Profiles,
+		Files:     flags.config.Files,
+	}
+	if !globalConfig.Output.Text() {
+		if flags.Output == "csv" {
+			return fmt.Errorf("config show does not support csv")
+		}
+		return globalConfig.Output.Write(shown)
+	}
+	if len(shown.Files) == 0 {
+		fmt.Println("# no config files")
+	}
+	for _, file := range shown.Files {
+		fmt.Println("# from", file)
+	}
+	text, err := yaml.Marshal(shown)
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(text))
+	return nil
+}
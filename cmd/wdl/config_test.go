@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v3"
+)
+
+// writeConfig writes a config file and returns its path
+func writeConfig(t *testing.T, dir string, name string, text string) string {
+	path := filepath.Join(dir, name)
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	assert.NoError(t, os.WriteFile(path, []byte(text), 0o644))
+	return path
+}
+
+func TestConfigMerge(t *testing.T) {
+	assert := assert.New(t)
+	count, first, salet, workers := 500, "crane", "salet", 2
+	config := Config{Count: &count, First: &first, Profiles: map[string]Config{"deep": {Workers: &workers}}, Files: []string{"user"}}
+	config.merge(Config{First: &salet, Profiles: map[string]Config{"deep": {First: &first}}, Files: []string{"project"}})
+	assert.Equal(500, *config.Count) // not set in over
+	assert.Equal("salet", *config.First)
+	assert.Equal(2, *config.Profiles["deep"].Workers) // profiles are merged by name
+	assert.Equal("crane", *config.Profiles["deep"].First)
+	assert.Equal([]string{"user", "project"}, config.Files)
+}
+
+func TestConfigResolvePaths(t *testing.T) {
+	assert := assert.New(t)
+	dict, guesses, history, builtin := "words/answers.txt", "/abs/guesses.txt", "-", "builtin:answers"
+	config := Config{Dict: &dict, Guesses: &guesses, History: &history, Stats: &builtin,
+		Profiles: map[string]Config{"deep": {Memo: &dict}}}
+	config.resolvePaths("/project")
+	assert.Equal("/project/words/answers.txt", *config.Dict)
+	assert.Equal("/abs/guesses.txt", *config.Guesses)
+	assert.Equal("-", *config.History) // stdin
+	assert.Equal("builtin:answers", *config.Stats)
+	assert.Equal("/project/words/answers.txt", *config.Profiles["deep"].Memo)
+	assert.Nil(config.Memo)
+}
+
+func TestLoadConfig(t *testing.T) {
+	assert := assert.New(t)
+	home := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", home)
+	writeConfig(t, home, "wdl/config.yaml", "count: 100\nfirst: crane\nprofile: fast\nprofiles:\n  fast:\n    workers: 1\n")
+	project := t.TempDir()
+	writeConfig(t, project, projectConfigName, "first: salet\ndict: answers.txt\nprofiles:\n  deep:\n    workers: 4\n    recursive: true\n")
+	sub := filepath.Join(project, "sub")
+	assert.NoError(os.Mkdir(sub, 0o755))
+	t.Chdir(sub) // the project file is found in a parent directory
+
+	// the project file wins over the user file, the profile in the files wins over both
+	config, err := loadConfig("", "")
+	assert.NoError(err)
+	assert.Len(config.Files, 2)
+	assert.Equal(100, *config.Count)
+	assert.Equal("salet", *config.First)
+	assert.Equal(filepath.Join(project, "answers.txt"), *config.Dict)
+	assert.Equal("fast", *config.Profile)
+	assert.Equal(1, *config.Workers)
+
+	// --profile wins over the profile in the files
+	config, err = loadConfig("", "deep")
+	assert.NoError(err)
+	assert.Equal(4, *config.Workers)
+	assert.True(*config.Recursive)
+	_, err = loadConfig("", "nope")
+	assert.Error(err)
+
+	// --config is used instead of both
+	other := writeConfig(t, t.TempDir(), "other.yaml", "count: 7\n")
+	config, err = loadConfig(other, "")
+	assert.NoError(err)
+	assert.Equal([]string{other}, config.Files)
+	assert.Equal(7, *config.Count)
+	assert.Nil(config.First)
+
+	bad := writeConfig(t, t.TempDir(), "bad.yaml", "cuont: 7\n")
+	_, err = loadConfig(bad, "")
+	assert.Error(err)
+}
+
+func TestConfigure(t *testing.T) {
+	assert := assert.New(t)
+	fromFile := 7
+	run := func(args ...string) int {
+		count := 0
+		cmd := &cli.Command{
+			Name:  "wdl",
+			Flags: []cli.Flag{&cli.IntFlag{Name: "count", Destination: &count}},
+			Action: func(ctx context.Context, cmd *cli.Command) error {
+				configure(cmd, "count", &fromFile, &count)
+				return nil
+			},
+		}
+		assert.NoError(cmd.Run(context.Background(), append([]string{"wdl"}, args...)))
+		return count
+	}
+	assert.Equal(7, run())               // the file when the flag is not provided
+	assert.Equal(3, run("--count", "3")) // the flag wins over the file
+	assert.Equal(0, run("--count", "0")) // even when it is the default
+}
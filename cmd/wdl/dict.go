@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/powellquiring/gowordle/gowordle"
+)
+
+// builtinDictionaries are audited when no files are provided
+var builtinDictionaries = []string{gowordle.BuiltinPrefix + "answers", gowordle.BuiltinPrefix + "answers_orig", gowordle.BuiltinPrefix + "guesses"}
+
+// dictAudit prints the problems found in each dictionary, returns false if there were any
+func dictAudit(out *output, paths []string) (bool, error) {
+	if len(paths) == 0 {
+		paths = builtinDictionaries
+	}
+	clean := true
+	for _, path := range paths {
+		findings, err := gowordle.AuditDictionaryFile(path)
+		if err != nil {
+			return false, err
+		}
+		if out.Text() {
+			fmt.Println(path, len(findings), "findings")
+		}
+		for _, finding := range findings {
+			if out.Text() {
+				fmt.Printf("%s:%d %q %s\n", path, finding.Line, finding.Word, finding.Problem)
+			} else if err := out.Write(auditRecord{path, finding.Line, finding.Word, finding.Problem}); err != nil {
+				return false, err
+			}
+		}
+		clean = clean && len(findings) == 0
+	}
+	return clean, nil
+}
+
+// dictDiff prints the answers added and removed going from a to b and how each one moves the best first guess
+func dictDiff(globalConfig GlobalConfiguration, a, b string) error {
+	dictA, err := gowordle.LoadDictionaryFile(a)
+	if err != nil {
+		return err
+	}
+	dictB, err := gowordle.LoadDictionaryFile(b)
+	if err != nil {
+		return err
+	}
+	out := globalConfig.Output
+	diff := gowordle.DiffDictionaries(globalConfig.Words.Guesses, dictA.Words, dictB.Words)
+	if !out.Text() {
+		for _, shift := range diff.Shifts {
+			change := "removed"
+			if shift.Added {
+				change = "added"
+			}
+			if err := out.Write(diffRecord{shift.Word, change, shift.Best, shift.Score, shift.BestChanged}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	fmt.Println(a, len(dictA.Words), "words", dictA.Hash)
+	fmt.Println(b, len(dictB.Words), "words", dictB.Hash)
+	fmt.Println("added:", len(diff.Added), "removed:", len(diff.Removed))
+	fmt.Println("best first guess", a, diff.BestA, diff.ScoreA)
+	fmt.Println("best first guess", b, diff.BestB, diff.ScoreB)
+	for _, shift := range diff.Shifts {
+		change := "-"
+		if shift.Added {
+			change = "+"
+		}
+		moved := ""
+		if shift.BestChanged {
+			moved = " changed"
+		}
+		fmt.Printf("%s%s best %s %d%s\n", change, shift.Word, shift.Best, shift.Score, moved)
+	}
+	return nil
+}
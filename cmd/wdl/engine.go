@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/powellquiring/gowordle/gowordle"
+	"github.com/schollz/progressbar/v3"
+)
+
+// arenaOptions are the arena command flags, the answers are picked like measure
+type arenaOptions struct {
+	measureOptions
+	Engines  []string // engine command lines
+	MoveTime time.Duration
+}
+
+// defaultStrategy is the first strategy or recursive with --recursive
+func defaultStrategy(globalConfig GlobalConfiguration) string {
+	if globalConfig.Recursive {
+		return "recursive"
+	}
+	return gowordle.Strategies[0].Name
+}
+
+// engine runs the engine protocol on stdin and stdout
+func engine(globalConfig GlobalConfiguration, strategyName string) error {
+	if strategyName == "" {
+		strategyName = defaultStrategy(globalConfig)
+	}
+	strategy, err := gowordle.FindStrategy(strategyName)
+	if err != nil {
+		return err
+	}
+	e := gowordle.NewEngine(globalConfig.Words, gowordle.WordleWord([]rune(globalConfig.FirstWord)), strategy)
+	return e.Run(os.Stdin, os.Stdout)
+}
+
+// arena plays each engine over the same answers and compares them like measure
+func arena(globalConfig GlobalConfiguration, options arenaOptions, args []string) error {
+	if len(options.Engines) == 0 {
+		return fmt.Errorf(`provide the engines with --engine, like --engine "wdl engine"`)
+	}
+	answers, err := measureAnswers(globalConfig, options.measureOptions, args)
+	if err != nil {
+		return err
+	}
+	results := []gowordle.BenchmarkResult{}
+	for _, command := range options.Engines {
+		var bar *progressbar.ProgressBar
+		if globalConfig.progress {
+			bar = progressbar.Default(int64(len(answers)), command)
+		} else {
+			bar = progressbar.DefaultSilent(int64(len(answers)))
+		}
+		progress := func(gowordle.BenchmarkGame) { bar.Add(1) }
+		result, err := gowordle.RunArena(strings.Fields(command), globalConfig.Words, answers, options.MaxGuesses, options.MoveTime, options.Workers, progress)
+		if err != nil {
+			return err
+		}
+		for _, other := range results {
+			if other.Strategy == result.Strategy {
+				result.Strategy = command
+			}
+		}
+		results = append(results, result)
+		if !globalConfig.Output.Text() {
+			for _, game := range result.Games {
+				record := measureRecord{result.Strategy, game.Answer, game.Guesses, game.Colors, len(game.Guesses), game.Solved, game.Duration.Microseconds()}
+				if err := globalConfig.Output.Write(record); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		printBenchmark(result, options.measureOptions)
+	}
+	if globalConfig.Output.Text() && len(results) > 1 {
+		printDisagreements(results)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/powellquiring/gowordle/gowordle"
+)
+
+// events sends the progress of sim, measure and first for --events: stderr writes a JSON line per event, an address
+// like localhost:8081 serves them as Server-Sent Events on /events and a POST to /cancel cancels the run
+type events struct {
+	sinks  []func(gowordle.ProgressEvent)
+	server *http.Server
+}
+
+// newEvents starts the events for the --events value, nil if it is empty.  cancel is called for /cancel.
+func newEvents(value string, cancel func()) (*events, error) {
+	switch value {
+	case "":
+		return nil, nil
+	case "stderr":
+		return &events{sinks: []func(gowordle.ProgressEvent){gowordle.ProgressJSONLines(os.Stderr)}}, nil
+	}
+	listener, err := net.Listen("tcp", value)
+	if err != nil {
+		return nil, fmt.Errorf("--events %s: %w", value, err)
+	}
+	progressServer := gowordle.NewProgressServer(cancel)
+	ret := &events{sinks: []func(gowordle.ProgressEvent){progressServer.Send},
+		server: &http.Server{Handler: progressServer.Handler(), ReadHeaderTimeout: 10 * time.Second}}
+	fmt.Fprintf(os.Stderr, "progress events on http://%s/events\n", listener.Addr())
+	go ret.server.Serve(listener)
+	return ret, nil
+}
+
+// progress starts a job, nil if there are no events
+func (e *events) progress(job string, total int) *gowordle.Progress {
+	if e == nil {
+		return nil
+	}
+	return gowordle.NewProgress(job, total, e.sinks...)
+}
+
+// Close waits a little for the clients to get the last event, clients that are still connected after that are cut
+// off, the run itself is finished so that is not an error
+func (e *events) Close() error {
+	if e == nil || e.server == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := e.server.Shutdown(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		fmt.Fprintln(os.Stderr, "progress events clients did not disconnect in time, closing them")
+		return e.server.Close()
+	}
+	return err
+}
+
+// finish sends the last event of the job, cancelled if the context was
+func finish(ctx context.Context, progress *gowordle.Progress) {
+	if ctx.Err() != nil {
+		progress.Finish(gowordle.ProgressCancelled)
+	} else {
+		progress.Finish(gowordle.ProgressDone)
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/powellquiring/gowordle/gowordle"
+)
+
+// palette is how tiles are drawn, the ANSI escape for each color or brackets for a palette without color
+type palette struct {
+	tiles        map[rune]string // g, y, r and ' ' for a key that has not been guessed
+	brackets     map[rune][2]string
+	highContrast bool // share grid uses orange and blue
+}
+
+const ansiReset = "\x1b[0m"
+
+var palettes = map[string]palette{
+	"default": {tiles: map[rune]string{
+		'g': "\x1b[1;97;42m",
+		'y': "\x1b[1;30;43m",
+		'r': "\x1b[1;97;100m",
+		' ': "\x1b[1;30;47m",
+	}},
+	// orange and blue are easier to tell apart for red green color blindness
+	"contrast": {tiles: map[rune]string{
+		'g': "\x1b[1;97;48;5;208m",
+		'y': "\x1b[1;97;44m",
+		'r': "\x1b[1;97;100m",
+		' ': "\x1b[1;30;47m",
+	}, highContrast: true},
+	// no color at all: [A] green, (A) yellow, A not in the word
+	"symbols": {brackets: map[rune][2]string{
+		'g': {"[", "]"},
+		'y': {"(", ")"},
+		'r': {" ", " "},
+		' ': {" ", " "},
+	}},
+}
+
+// tile draws one letter in the color
+func (p palette) tile(letter rune, color rune) string {
+	text := strings.ToUpper(string(letter))
+	if p.brackets != nil {
+		return p.brackets[color][0] + text + p.brackets[color][1]
+	}
+	return p.tiles[color] + " " + text + " " + ansiReset
+}
+
+var keyboardRows = []string{"qwertyuiop", "asdfghjkl", "zxcvbnm"}
+
+// printBoard prints the guesses so far and the keyboard with the status of each letter
+func printBoard(game *gowordle.Game, p palette) {
+	for _, ga := range game.Guesses {
+		for i, letter := range ga.Guess {
+			fmt.Print(p.tile(letter, ga.Answer[i]))
+		}
+		fmt.Println()
+	}
+	fmt.Println()
+	keyboard := game.Keyboard()
+	for i, row := range keyboardRows {
+		fmt.Print(strings.Repeat(" ", i))
+		for _, letter := range row {
+			color, ok := keyboard[letter]
+			if !ok {
+				color = ' '
+			}
+			fmt.Print(p.tile(letter, color))
+		}
+		fmt.Println()
+	}
+}
+
+// gameAnswer is the answer for --puzzle or --date from the history, otherwise a random answer
+func gameAnswer(globalConfig GlobalConfiguration, seed int64) (string, error) {
+	if globalConfig.Puzzle != noPuzzle {
+		answer, ok := globalConfig.History.Answers[globalConfig.Puzzle]
+		if !ok {
+			return "", fmt.Errorf("no answer for puzzle %d in the history", globalConfig.Puzzle)
+		}
+		return answer, nil
+	}
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	r := rand.New(rand.NewSource(seed))
+	return globalConfig.Answers[r.Intn(len(globalConfig.Answers))], nil
+}
+
+// playGame hosts a game for a person reading guesses from in
+func playGame(globalConfig GlobalConfiguration, in io.Reader, seed int64, maxGuesses int, paletteName string) error {
+	p, ok := palettes[paletteName]
+	if !ok {
+		return fmt.Errorf("unknown palette %s, use default, contrast or symbols", paletteName)
+	}
+	answer, err := gameAnswer(globalConfig, seed)
+	if err != nil {
+		return err
+	}
+	game := gowordle.NewGame(globalConfig.Words, gowordle.WordleWord([]rune(answer)), maxGuesses)
+	scanner := bufio.NewScanner(in)
+	printBoard(game, p)
+	for !game.Over() {
+		fmt.Printf("guess %d of %d> ", len(game.Guesses)+1, maxGuesses)
+		if !scanner.Scan() {
+			fmt.Println()
+			fmt.Println("the answer was", answer)
+			return scanner.Err()
+		}
+		guess, err := gowordle.ParseGuess(strings.ToLower(strings.TrimSpace(scanner.Text())))
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		if _, err := game.Guess(guess); err != nil {
+			fmt.Println(err)
+			continue
+		}
+		printBoard(game, p)
+	}
+	if game.Solved() {
+		fmt.Println("solved in", len(game.Guesses))
+	} else {
+		fmt.Println("the answer was", answer)
+	}
+	fmt.Println()
+	fmt.Print(game.ShareGrid(globalConfig.Puzzle, p.highContrast))
+	return nil
+}
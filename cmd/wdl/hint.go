@@ -0,0 +1,51 @@
+package main
+
+import "fmt"
+
+// hint prints the hint at the level after the guess/answer pairs
+func hint(globalConfig GlobalConfiguration, level int, pairs []string) error {
+	session, err := replaySession(globalConfig, pairs)
+	if err != nil {
+		return err
+	}
+	h, err := session.Hint(level)
+	if err != nil {
+		return err
+	}
+	if !globalConfig.Output.Text() {
+		record := hintRecord{Level: h.Level, Remaining: h.Remaining, LetterAnswers: h.LetterAnswers, Spot: h.Spot,
+			SpotLetters: h.SpotLetters, Repeated: h.Repeated, GuessAfter: h.GuessAfter, Opener: h.Opener}
+		for _, letter := range h.Letters {
+			record.Letters = append(record.Letters, string(letter))
+		}
+		if level >= 4 {
+			record.Guess = string(h.Guess[:])
+		}
+		return globalConfig.Output.Write(record)
+	}
+	fmt.Println(h.Remaining, "possible answers")
+	if level >= 2 {
+		fmt.Print("letters that split the answers best:")
+		for i, letter := range h.Letters {
+			fmt.Printf(" %c (%d of %d)", letter, h.LetterAnswers[i], h.Remaining)
+		}
+		fmt.Println()
+		fmt.Println("spot", h.Spot, "has the most even spread,", h.SpotLetters, "different letters")
+	}
+	if level >= 3 {
+		switch h.Repeated {
+		case 0:
+			fmt.Println("no possible answer has a repeated letter")
+		case h.Remaining:
+			fmt.Println("the answer has a repeated letter")
+		default:
+			fmt.Println(h.Repeated, "of", h.Remaining, "possible answers have a repeated letter")
+		}
+	}
+	if level >= 4 && h.Opener {
+		fmt.Printf("opener %s, the configured first guess, leaves %.2f answers on average\n", string(h.Guess[:]), h.GuessAfter)
+	} else if level >= 4 {
+		fmt.Printf("best guess %s leaves %.2f answers on average\n", string(h.Guess[:]), h.GuessAfter)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/powellquiring/gowordle/gowordle"
+)
+
+const interactiveHelp = `guess feedback  apply a guess and the answer colors, like: raise ryyrr
+undo            remove the last guess
+reset           start a new game
+show            show the guesses, the suggestion and the possible answers
+top N           the N best guesses, default 10
+why word        explain if the word is still possible
+help            this message
+quit            exit`
+
+// maxShown is the most possible answers printed after each guess
+const maxShown = 50
+
+// interactive plays along with a live game reading commands from in
+func interactive(globalConfig GlobalConfiguration, in io.Reader) error {
+	session := gowordle.NewSession(globalConfig.Words, gowordle.WordleWord([]rune(globalConfig.FirstWord)))
+	scanner := bufio.NewScanner(in)
+	fmt.Println(interactiveHelp)
+	showSession(session, false)
+	for fmt.Print("> "); scanner.Scan(); fmt.Print("> ") {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		switch strings.ToLower(fields[0]) {
+		case "quit", "exit", "q":
+			return nil
+		case "help", "?":
+			fmt.Println(interactiveHelp)
+		case "undo":
+			if !session.Undo() {
+				fmt.Println("nothing to undo")
+				continue
+			}
+			showSession(session, false)
+		case "reset":
+			session.Reset()
+			showSession(session, false)
+		case "show":
+			showSession(session, true)
+		case "top":
+			n := 10
+			if len(fields) > 1 {
+				var err error
+				if n, err = strconv.Atoi(fields[1]); err != nil || n < 1 {
+					fmt.Println("top N, N must be a positive number")
+					continue
+				}
+			}
+			for i, item := range session.Top(n) {
+				fmt.Println(i+1, string(item.Value[:]), item.Score)
+			}
+		case "why":
+			if len(fields) != 2 {
+				fmt.Println("why word")
+				continue
+			}
+			word, err := gowordle.ParseGuess(strings.ToLower(fields[1]))
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+			fmt.Println(session.Why(word))
+		default:
+			if len(fields) != 2 {
+				fmt.Println("expected a guess and feedback, help for the commands")
+				continue
+			}
+			guess, err := validGuess(globalConfig, strings.ToLower(fields[0]))
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+			answer, err := gowordle.ParseFeedback(fields[1])
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+			if err := session.Apply(guess, answer); err != nil {
+				fmt.Println(err)
+				continue
+			}
+			showSession(session, false)
+		}
+	}
+	fmt.Println()
+	return scanner.Err()
+}
+
+// showSession prints the suggestion and the possible answers, with history the guesses so far
+func showSession(session *gowordle.Session, history bool) {
+	if history {
+		for i, ga := range session.History {
+			fmt.Println(i+1, string(ga.Guess[:]), gowordle.FormatFeedback(ga.Answer))
+		}
+	}
+	possible := session.Possible()
+	if len(possible) == 1 {
+		fmt.Println("solved:", string(possible[0][:]))
+		return
+	}
+	suggestion := session.Suggest()
+	fmt.Print("suggestion: ", string(suggestion[:]), " possible: ", len(possible))
+	for i, word := range possible {
+		if i == maxShown {
+			fmt.Print(" ...")
+			break
+		}
+		fmt.Print(" ", string(word[:]))
+	}
+	fmt.Println()
+}
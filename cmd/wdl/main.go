@@ -1,35 +1,104 @@
 package main
 
 import (
-	"container/heap"
 	"context"
 	"fmt"
 	"log"
+	"math/rand"
 	"os"
+	"os/signal"
+	"runtime"
+	"slices"
 	"sort"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/powellquiring/gowordle/gowordle"
 	"github.com/schollz/progressbar/v3"
 	"github.com/urfave/cli/v3" // imports as package "cli"
 )
 
-func server(globalConfig GlobalConfiguration, solution string, guesses []string) {
-	wordList := globalConfig.AllWords
-	wws := gowordle.StringsToWordleWords(wordList)
-	fmt.Print(solution, " ")
+func server(globalConfig GlobalConfiguration, solution string, guesses []string) error {
+	if !slices.Contains(globalConfig.Answers, solution) {
+		return fmt.Errorf("solution %s is not in the answer list", solution)
+	}
+	wws := globalConfig.Words.Answers
+	out := globalConfig.Output
+	if out.Text() {
+		fmt.Print(solution, " ")
+	}
 	solutionWW := gowordle.WordleWord([]rune(solution))
 	for _, guess := range guesses {
 		game := gowordle.NewWordleMatcher(wws)
-		guessWW := gowordle.WordleWord([]rune(guess))
+		guessWW, err := validGuess(globalConfig, guess)
+		if err != nil {
+			return err
+		}
 		answer := gowordle.WordleAnswer2(solutionWW, guessWW)
 		wws = game.Matching2(answer)
-		fmt.Println(guess, string(answer.Colors[:]), gowordle.WordleWordsToStrings(wws))
+		if out.Text() {
+			fmt.Println(guess, gowordle.FormatFeedback(answer.Colors), gowordle.WordleWordsToStrings(wws))
+		} else if err := out.Write(serverRecord{guess, string(answer.Colors[:]), len(wws), gowordle.WordleWordsToStrings(wws), false}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validGuess returns the guess if it is in the allowed guesses
+func validGuess(globalConfig GlobalConfiguration, guess string) (gowordle.WordleWord, error) {
+	guessWW, err := gowordle.ParseGuess(guess)
+	if err != nil {
+		return guessWW, err
+	}
+	if !globalConfig.Words.ValidGuess(guessWW) {
+		return guessWW, fmt.Errorf("guess %s is not in the guess list", guess)
+	}
+	return guessWW, nil
+}
+
+// absurdleServer is an adversarial server, it never commits to a solution and keeps the most words alive.  upperBound
+// marks the records of a width limited solve.
+func absurdleServer(globalConfig GlobalConfiguration, guesses []string, upperBound bool) error {
+	game := gowordle.NewAbsurdleGame(globalConfig.Words.Answers)
+	for _, guess := range guesses {
+		guessWW, err := validGuess(globalConfig, guess)
+		if err != nil {
+			return err
+		}
+		answer := game.Guess(guessWW)
+		if globalConfig.Output.Text() {
+			fmt.Println(guess, gowordle.FormatFeedback(answer), len(game.Possible), gowordle.WordleWordsToStrings(game.Possible))
+		} else if err := globalConfig.Output.Write(serverRecord{guess, string(answer[:]), len(game.Possible), gowordle.WordleWordsToStrings(game.Possible), upperBound}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// absurdleSolve prints the fewest guesses that are guaranteed to beat the absurdle server
+func absurdleSolve(globalConfig GlobalConfiguration, maxGuesses int, width int) bool {
+	guesses, ok := gowordle.AbsurdleSolve(globalConfig.Words.Guesses, globalConfig.Words.Answers, maxGuesses, width)
+	if !ok {
+		if globalConfig.Output.Text() {
+			fmt.Println("no win in", maxGuesses, "guesses")
+		}
+		return false
+	}
+	if globalConfig.Output.Text() {
+		if width > 0 {
+			fmt.Println(len(guesses), "guesses, an upper bound, only", width, "guesses were tried each turn, --width 0 finds the fewest")
+		} else {
+			fmt.Println(len(guesses), "guesses")
+		}
 	}
+	return absurdleServer(globalConfig, gowordle.WordleWordsToStrings(guesses), width > 0) == nil
 }
 
 /**
 func FirstWords(globalConfig GlobalConfiguration) {
-	wordList := globalConfig.AllWords
+	wordList := globalConfig.Answers
 	results := gowordle.UniqueGuessResults(wordList, globalConfig.FirstWord)
 	for unique, solutionAnswers := range results {
 		fmt.Println(len(unique)/6, unique, solutionAnswers.AnswerColors, solutionAnswers.Solutions)
@@ -37,62 +106,195 @@ func FirstWords(globalConfig GlobalConfiguration) {
 }
 	**/
 
-func FirstWordsByAnswerColor(globalConfig GlobalConfiguration) {
-	wordList := globalConfig.AllWords
+func FirstWordsByAnswerColor(globalConfig GlobalConfiguration) error {
+	wordList := globalConfig.Answers
 	results := gowordle.UniqueAnswerResults(wordList, globalConfig.FirstWord)
 	sortedAnswerColors := []string{}
 	for answerColors, _ := range results {
 		sortedAnswerColors = append(sortedAnswerColors, answerColors)
 	}
 	sort.Strings(sortedAnswerColors)
-	fmt.Println(globalConfig.FirstWord, "--- distribution of solutions when using this guess")
+	out := globalConfig.Output
+	if out.Text() {
+		fmt.Println(globalConfig.FirstWord, "--- distribution of solutions when using this guess")
+	}
 	for _, answerColors := range sortedAnswerColors {
 		solutions := results[answerColors]
-		fmt.Println(len(solutions), answerColors, solutions)
+		if out.Text() {
+			fmt.Println(len(solutions), answerColors, solutions)
+		} else if err := out.Write(colorRecord{globalConfig.FirstWord, answerColors, len(solutions), solutions}); err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
-func FirstWords(globalConfig GlobalConfiguration) {
-	wordList := globalConfig.AllWords
-	wws := gowordle.StringsToWordleWords(wordList)
-	ret := gowordle.ScoreAlgorithmTotalMatches1LevelAll(wws, wws, wws, 0, len(wordList))
-	for ret.Len() > 0 {
-		item := heap.Pop(ret).(gowordle.Item)
-		fmt.Println(item.Score, string(item.Value[:]))
+func FirstWords(ctx context.Context, globalConfig GlobalConfiguration) error {
+	progress := globalConfig.events.progress("first", len(globalConfig.Words.Guesses))
+	ranked, err := gowordle.RankGuessesContext(ctx, globalConfig.Words.GuessList(), globalConfig.Words.Answers, progress)
+	finish(ctx, progress)
+	if err != nil {
+		return fmt.Errorf("first cancelled: %w", err)
+	}
+	for _, item := range ranked {
+		if globalConfig.Output.Text() {
+			fmt.Println(item.Score, string(item.Value[:]))
+		} else if err := globalConfig.Output.Write(firstRecord{string(item.Value[:]), item.Score}); err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
-func simulate(globalConfig GlobalConfiguration, answers []string) {
-	wordList := globalConfig.AllWords
+// simOptions are the sim command flags
+type simOptions struct {
+	Workers    int
+	Checkpoint string // file of finished games, resumed if it exists
+}
+
+func simulate(ctx context.Context, globalConfig GlobalConfiguration, maxGuesses int, answers []string, options simOptions) error {
 	if len(answers) == 0 {
-		answers = wordList
+		answers = globalConfig.Answers
+	}
+	sortedGames := make(map[int][]gowordle.SimulateResult)
+	failedGames := make([]gowordle.SimulateResult, 0)
+	pruning := &pruningSummary{}
+	out := globalConfig.Output
+
+	// with --puzzle or --date the answers used by earlier puzzles can not be the answer, they are skipped.  Otherwise
+	// answers that are not in the answer list are added to it so they can be played.
+	words := globalConfig.Words
+	added := []string{}
+	playable := make([]string, 0, len(answers))
+	for answerCount, answer := range answers {
+		if !slices.Contains(globalConfig.Answers, answer) {
+			if globalConfig.Unpruned == nil {
+				if _, err := gowordle.ParseGuess(answer); err != nil {
+					return err
+				}
+				added = append(added, answer)
+			} else {
+				if out.Text() {
+					fmt.Println(answerCount, len(answers), " ", answer, ": SKIPPED not a possible answer after pruning")
+				} else {
+					fmt.Fprintln(os.Stderr, answer, "skipped, not a possible answer after pruning")
+				}
+				continue
+			}
+		}
+		playable = append(playable, answer)
 	}
-	type Game struct {
-		Answer  string
-		Guesses []string
+	if len(added) > 0 {
+		words = gowordle.NewWordLists(append(append([]string{}, globalConfig.Answers...), added...), gowordle.WordleWordsToStrings(words.Guesses))
+		if out.Text() {
+			fmt.Println("added to the answer list:", strings.Join(added, " "))
+		}
+	}
+
+	strategy := gowordle.Strategy{Name: "matches", BestGuess: gowordle.BestGuess1}
+	if globalConfig.Recursive {
+		strategy.Name = "recursive"
+	}
+	finished := make(map[string]gowordle.SimulateResult)
+	var checkpoint *gowordle.Checkpoint
+	if options.Checkpoint != "" {
+		var err error
+		header := gowordle.CheckpointHeader{Words: words.Hash, FirstGuess: globalConfig.FirstWord, MaxGuesses: maxGuesses, Strategy: strategy.Name}
+		if checkpoint, err = gowordle.OpenCheckpoint(options.Checkpoint, header); err != nil {
+			return err
+		}
+		defer checkpoint.Close()
+		for answer, game := range checkpoint.Games {
+			finished[answer] = game
+		}
+	}
+	remaining := make([]string, 0, len(playable))
+	for _, answer := range playable {
+		if game, ok := finished[answer]; !ok {
+			remaining = append(remaining, answer)
+		} else if !out.Text() {
+			if err := out.Write(gameRecord{game.Answer, game.Guesses, game.Colors, len(game.Guesses), game.Solved}); err != nil {
+				return err
+			}
+		}
+	}
+	if out.Text() && len(remaining) < len(playable) {
+		fmt.Println(len(playable)-len(remaining), "games resumed from", options.Checkpoint)
 	}
-	sortedGames := make(map[int][]Game)
 
 	var bar *progressbar.ProgressBar
 	if globalConfig.progress {
-		bar = progressbar.Default(int64(len(answers)))
+		bar = progressbar.Default(int64(len(remaining)))
 	} else {
-		bar = progressbar.DefaultSilent(int64(len(answers)))
+		bar = progressbar.DefaultSilent(int64(len(remaining)))
 	}
-
-	for answerCount, answer := range answers {
+	gameCount := len(playable) - len(remaining)
+	progress := globalConfig.events.progress("sim", len(remaining))
+	var doneErr error
+	_, err := gowordle.RunBenchmarkContext(ctx, words, strategy, remaining, globalConfig.FirstWord, maxGuesses, options.Workers, func(played gowordle.BenchmarkGame) {
 		bar.Add(1)
-		guesses := gowordle.Simulate(wordList, answer, globalConfig.FirstWord)
-		fmt.Print(answerCount, len(answers), " ", answer, ":")
-		for _, guess := range guesses {
+		game := played.SimulateResult
+		progress.Game(game)
+		finished[game.Answer] = game
+		if checkpoint != nil && doneErr == nil {
+			doneErr = checkpoint.Add(game)
+		}
+		if !out.Text() {
+			if err := out.Write(gameRecord{game.Answer, game.Guesses, game.Colors, len(game.Guesses), game.Solved}); err != nil && doneErr == nil {
+				doneErr = err
+			}
+			return
+		}
+		fmt.Print(gameCount, len(playable), " ", game.Answer, ":")
+		for _, guess := range game.Guesses {
 			fmt.Print(" ", guess)
 		}
+		if !game.Solved {
+			fmt.Print(" FAILED")
+		}
 		fmt.Println()
+		gameCount++
+	})
+	finish(ctx, progress)
+	if doneErr != nil {
+		return doneErr
+	}
+	if err != nil {
+		message := fmt.Sprintf("sim cancelled after %d of %d games", len(finished), len(playable))
+		if checkpoint != nil {
+			message += ", run it again to resume from " + options.Checkpoint
+		}
+		return fmt.Errorf("%s: %w", message, err)
+	}
 
-		if _, ok := sortedGames[len(guesses)]; !ok {
-			sortedGames[len(guesses)] = make([]Game, 0)
+	var unpruned map[string]gowordle.SimulateResult
+	if globalConfig.Unpruned != nil {
+		if unpruned, err = simulateUnpruned(ctx, globalConfig, strategy, playable, maxGuesses, options); err != nil {
+			return err
+		}
+	}
+	solvedGuesses := 0
+	for _, answer := range playable {
+		game := finished[answer]
+		if globalConfig.Unpruned != nil {
+			pruning.add(game, unpruned[answer])
+		}
+		if !game.Solved {
+			failedGames = append(failedGames, game)
+		} else {
+			sortedGames[len(game.Guesses)] = append(sortedGames[len(game.Guesses)], game)
+			solvedGuesses += len(game.Guesses)
+		}
+	}
+	if !out.Text() {
+		summary := simSummaryRecord{Games: len(playable), Failed: len(failedGames), MaxGuesses: maxGuesses}
+		if solved := len(playable) - len(failedGames); solved > 0 {
+			summary.Average = float64(solvedGuesses) / float64(solved)
+		}
+		if globalConfig.Unpruned != nil {
+			pruning.record(&summary, len(globalConfig.Unpruned.Answers)-len(globalConfig.Words.Answers))
 		}
-		sortedGames[len(guesses)] = append(sortedGames[len(guesses)], Game{answer, guesses})
+		return out.Write(summary)
 	}
 	fmt.Println("---------------------")
 
@@ -108,31 +310,243 @@ func simulate(globalConfig GlobalConfiguration, answers []string) {
 		games := sortedGames[numGuesses]
 		fmt.Println(numGuesses, len(games), " ---------------------")
 		for _, game := range games {
-			fmt.Print(game.Answer, ":")
-			for _, guess := range game.Guesses {
-				fmt.Print(" ", guess)
+			printGame(game)
+		}
+	}
+	failedPercent := 0.0
+	if len(playable) > 0 {
+		failedPercent = 100 * float64(len(failedGames)) / float64(len(playable))
+	}
+	fmt.Printf("failed %d of %d games (%.2f%%) with %d guesses ---------------------\n", len(failedGames), len(playable), failedPercent, maxGuesses)
+	for _, game := range failedGames {
+		printGame(game)
+	}
+	if globalConfig.Unpruned != nil {
+		pruning.print(len(globalConfig.Unpruned.Answers)-len(globalConfig.Words.Answers), maxGuesses)
+	}
+	return nil
+}
+
+// simulateUnpruned plays the games again with the answers before pruning to compare, with a checkpoint the games
+// are saved to the checkpoint file with .unpruned added
+func simulateUnpruned(ctx context.Context, globalConfig GlobalConfiguration, strategy gowordle.Strategy, playable []string, maxGuesses int, options simOptions) (map[string]gowordle.SimulateResult, error) {
+	finished := make(map[string]gowordle.SimulateResult)
+	var checkpoint *gowordle.Checkpoint
+	path := options.Checkpoint + ".unpruned"
+	if options.Checkpoint != "" {
+		var err error
+		header := gowordle.CheckpointHeader{Words: globalConfig.Unpruned.Hash, FirstGuess: globalConfig.FirstWord, MaxGuesses: maxGuesses, Strategy: strategy.Name}
+		if checkpoint, err = gowordle.OpenCheckpoint(path, header); err != nil {
+			return nil, err
+		}
+		defer checkpoint.Close()
+		for answer, game := range checkpoint.Games {
+			finished[answer] = game
+		}
+	}
+	remaining := make([]string, 0, len(playable))
+	for _, answer := range playable {
+		if _, ok := finished[answer]; !ok {
+			remaining = append(remaining, answer)
+		}
+	}
+	progress := globalConfig.events.progress("sim unpruned", len(remaining))
+	var doneErr error
+	_, err := gowordle.RunBenchmarkContext(ctx, globalConfig.Unpruned, strategy, remaining, globalConfig.FirstWord, maxGuesses, options.Workers, func(played gowordle.BenchmarkGame) {
+		game := played.SimulateResult
+		progress.Game(game)
+		finished[game.Answer] = game
+		if checkpoint != nil && doneErr == nil {
+			doneErr = checkpoint.Add(game)
+		}
+	})
+	finish(ctx, progress)
+	if doneErr != nil {
+		return nil, doneErr
+	}
+	if err != nil {
+		message := fmt.Sprintf("sim cancelled after %d of %d games without pruning", len(finished), len(playable))
+		if checkpoint != nil {
+			message += ", run it again to resume from " + path
+		}
+		return nil, fmt.Errorf("%s: %w", message, err)
+	}
+	return finished, nil
+}
+
+// pruningSummary compares games played with the used answers removed to the same games with all the answers
+type pruningSummary struct {
+	games          int
+	guesses        [2]int // total guesses for the solved games, [0] pruned [1] unpruned
+	failed         [2]int
+	fewerGuesses   int // games solved in fewer guesses because of the pruning
+	greaterGuesses int
+}
+
+func (ps *pruningSummary) add(pruned, unpruned gowordle.SimulateResult) {
+	ps.games++
+	for i, game := range []gowordle.SimulateResult{pruned, unpruned} {
+		if game.Solved {
+			ps.guesses[i] += len(game.Guesses)
+		} else {
+			ps.failed[i]++
+		}
+	}
+	if len(pruned.Guesses) < len(unpruned.Guesses) {
+		ps.fewerGuesses++
+	} else if len(pruned.Guesses) > len(unpruned.Guesses) {
+		ps.greaterGuesses++
+	}
+}
+
+// record fills in the pruning fields of the sim summary
+func (ps *pruningSummary) record(summary *simSummaryRecord, removed int) {
+	summary.Pruned = true
+	summary.Removed = removed
+	summary.UnprunedFailed = ps.failed[1]
+	if solved := ps.games - ps.failed[1]; solved > 0 {
+		summary.UnprunedAverage = float64(ps.guesses[1]) / float64(solved)
+	}
+	summary.FewerGuesses = ps.fewerGuesses
+	summary.MoreGuesses = ps.greaterGuesses
+}
+
+func (ps *pruningSummary) print(removed int, maxGuesses int) {
+	fmt.Println("history pruning removed", removed, "used answers ---------------------")
+	for i, name := range []string{"pruned:", "all answers:"} {
+		if solved := ps.games - ps.failed[i]; solved > 0 {
+			fmt.Printf("%s average %.3f failed %d of %d with %d guesses\n", name, float64(ps.guesses[i])/float64(solved), ps.failed[i], ps.games, maxGuesses)
+		}
+	}
+	fmt.Println("fewer guesses with pruning:", ps.fewerGuesses, "more guesses with pruning:", ps.greaterGuesses)
+}
+
+func printGame(game gowordle.SimulateResult) {
+	fmt.Print(game.Answer, ":")
+	for _, guess := range game.Guesses {
+		fmt.Print(" ", guess)
+	}
+	fmt.Println()
+}
+
+// simulateMultiBoard plays games with one guess going to all boards at once.  answers are taken in groups of boards,
+// if no answers are provided games random groups of answers are played
+func simulateMultiBoard(globalConfig GlobalConfiguration, boards int, games int, seed int64, maxGuesses int, answers []string) error {
+	wordList := globalConfig.Answers
+	if len(answers) == 0 && boards > len(wordList) {
+		return cli.Exit(fmt.Sprintf("%d boards need %d different answers, there are only %d", boards, boards, len(wordList)), 1)
+	}
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	r := rand.New(rand.NewSource(seed))
+	tuples := make([][]string, 0)
+	if len(answers) > 0 {
+		for i := 0; i+boards <= len(answers); i += boards {
+			tuples = append(tuples, answers[i:i+boards])
+		}
+	} else {
+		for i := 0; i < games; i++ {
+			tuple := make([]string, 0, boards)
+			for _, index := range r.Perm(len(wordList))[0:boards] {
+				tuple = append(tuple, wordList[index])
+			}
+			tuples = append(tuples, tuple)
+		}
+	}
+
+	var bar *progressbar.ProgressBar
+	if globalConfig.progress {
+		bar = progressbar.Default(int64(len(tuples)))
+	} else {
+		bar = progressbar.DefaultSilent(int64(len(tuples)))
+	}
+
+	distribution := make(map[int]int)
+	failed := 0
+	totalGuesses := 0
+	for gameCount, tuple := range tuples {
+		bar.Add(1)
+		result := gowordle.SimulateMultiBoardWordLists(globalConfig.Words, tuple, globalConfig.FirstWord, maxGuesses)
+		if !globalConfig.Output.Text() {
+			if err := globalConfig.Output.Write(multiBoardRecord{result.Solutions, result.Guesses, result.SolvedAt, len(result.Guesses), result.Solved}); err != nil {
+				return err
 			}
-			fmt.Println()
+			continue
+		}
+		fmt.Print(gameCount, len(tuples), " ", tuple, ":")
+		for _, guess := range result.Guesses {
+			fmt.Print(" ", guess)
 		}
+		fmt.Println(" solved at", result.SolvedAt)
+		if !result.Solved {
+			failed++
+			continue
+		}
+		distribution[len(result.Guesses)]++
+		totalGuesses += len(result.Guesses)
+	}
+	if !globalConfig.Output.Text() {
+		return nil
+	}
+	fmt.Println("---------------------")
+	fmt.Println(boards, "boards", len(tuples), "games", "max guesses", maxGuesses, "seed", seed)
+	keys := make([]int, 0, len(distribution))
+	for k := range distribution {
+		keys = append(keys, k)
 	}
+	sort.Ints(keys)
+	for _, numGuesses := range keys {
+		fmt.Println(numGuesses, distribution[numGuesses])
+	}
+	if solved := len(tuples) - failed; solved > 0 {
+		fmt.Printf("average: %.3f\n", float64(totalGuesses)/float64(solved))
+	}
+	fmt.Printf("failed: %d (%.2f%%)\n", failed, 100*float64(failed)/float64(len(tuples)))
+	return nil
 }
 
 // playWordle with guess/answer pairs provided
-func playWordle(globalConfig GlobalConfiguration, answers []string) {
-	wordList := gowordle.StringsToWordleWords(globalConfig.AllWords)
-
+func playWordle(globalConfig GlobalConfiguration, answers []string, record bool) error {
 	gas := make([]gowordle.GuessAnswer, 0)
 	for i := 0; i < len(answers); i += 2 {
-		guess := answers[i]
-		answer := answers[i+1]
-		gas = append(gas, gowordle.GuessAnswer{Guess: gowordle.WordleWord([]rune(guess)), Answer: gowordle.WordleWord([]rune(answer))})
+		guess, err := gowordle.ParseGuess(answers[i])
+		if err != nil {
+			return err
+		}
+		answer, err := gowordle.ParseFeedback(answers[i+1])
+		if err != nil {
+			return err
+		}
+		gas = append(gas, gowordle.GuessAnswer{Guess: guess, Answer: answer})
 	}
-	nextGuess, possible := gowordle.PlayWorldReturnPossible(wordList, gas)
-	fmt.Print(string(nextGuess[:]), ":")
-	for _, word := range possible {
-		fmt.Print(" ", string(word[:]))
+	nextGuess, possible := gowordle.PlayWorldReturnPossible(globalConfig.Words.Guesses, globalConfig.Words.Answers, gas)
+	unprunedPossible := 0
+	if globalConfig.Unpruned != nil {
+		unpruned := globalConfig.Unpruned.Answers
+		for _, ga := range gas {
+			unpruned = gowordle.NewWordleMatcher(unpruned).Matching(ga.Guess, ga.Answer)
+		}
+		unprunedPossible = len(unpruned)
 	}
-	fmt.Println()
+	if !globalConfig.Output.Text() {
+		if err := globalConfig.Output.Write(playRecord{string(nextGuess[:]), gowordle.WordleWordsToStrings(possible), unprunedPossible}); err != nil {
+			return err
+		}
+	} else {
+		fmt.Print(string(nextGuess[:]), ":")
+		for _, word := range possible {
+			fmt.Print(" ", string(word[:]))
+		}
+		fmt.Println()
+		if globalConfig.Unpruned != nil {
+			fmt.Println("history pruning:", len(possible), "possible answers,", unprunedPossible, "without pruning")
+		}
+	}
+	if record {
+		return recordPlay(globalConfig, gas, possible)
+	}
+	return nil
 }
 
 type AnswerWords struct {
@@ -144,57 +558,177 @@ type GuessSolution struct {
 	Solution gowordle.WordleWord
 }
 
-func cache(globalConfig GlobalConfiguration) {
-	wordList := gowordle.StringsToWordleWords(globalConfig.AllWords)
-	game := gowordle.NewWordleMatcher(wordList)
+func cache(globalConfig GlobalConfiguration) error {
+	game := gowordle.NewWordleMatcher(globalConfig.Words.Answers)
 	result := make(map[GuessSolution]AnswerWords)
-	for _, guess := range wordList {
-		for _, solution := range wordList {
+	for _, guess := range globalConfig.Words.Guesses {
+		for _, solution := range globalConfig.Words.Answers {
 			answer := gowordle.WordleAnswer2(solution, guess)
 			matching := game.Matching2(answer)
 			answerWords := AnswerWords{answer, matching}
 			result[GuessSolution{guess, solution}] = answerWords
 		}
 	}
+	if !globalConfig.Output.Text() {
+		return globalConfig.Output.Write(cacheRecord{len(globalConfig.Words.Guesses), len(globalConfig.Words.Answers), len(result)})
+	}
 	fmt.Println("Done")
+	return nil
+}
+
+// GlobalFlags are the flags shared by all the commands
+type GlobalFlags struct {
+	Count     int
+	Recursive bool
+	Progress  bool
+	FirstWord string
+	Dict      string
+	Guesses   string
+	History   string
+	Puzzle    int
+	Date      string
+	Output    string
+	out       *output // created from Output before any command runs
+	Memo      string
+	Config    string
+	Profile   string
+	config    Config // from the config files, see loadConfig
+	Events    string
+	events    *events // started from Events before any command runs
+	Stats     string
 }
 
 type GlobalConfiguration struct {
-	AllWords  []string
+	Answers   []string
+	Words     *gowordle.WordLists // Answers along with the allowed guesses
+	Unpruned  *gowordle.WordLists // Words before the answers used by earlier puzzles were removed, nil if not pruned
+	History   *gowordle.AnswerHistory
+	Puzzle    int // from --puzzle or --date, noPuzzle if neither was provided
 	Recursive bool
 	progress  bool
 	FirstWord string
+	Output    *output // records for --output, nothing is written with text
+	events    *events // progress events for --events, nil without it
+	Stats     string  // --stats file of recorded games, empty for the default
 }
 
-func globalCofiguration(count int, recursive bool, progress bool, firstWord string) GlobalConfiguration {
-	if count == 0 {
-		count = len(gowordle.SortedWordleDictionary())
+// globalCofiguration uses the first count answers, 0 is all, from the --dict file or the built in answers.  The guesses
+// are the built in guesses along with the words in the --guesses file.  A file of - is stdin.  With --puzzle or --date
+// the answers used by earlier puzzles in the --history are removed after the count is applied.
+func globalCofiguration(flags GlobalFlags) GlobalConfiguration {
+	if flags.Dict == "-" && flags.Guesses == "-" {
+		log.Fatal("only one of --dict and --guesses can be read from stdin")
+	}
+	answers := gowordle.SortedWordleDictionary()
+	if flags.Dict != "" {
+		dict, err := gowordle.LoadDictionaryFile(flags.Dict)
+		if err != nil {
+			log.Fatal(err)
+		}
+		answers = dict.Words
+	}
+	guesses := []string{}
+	if flags.Guesses != "" {
+		dict, err := gowordle.LoadDictionaryFile(flags.Guesses)
+		if err != nil {
+			log.Fatal(err)
+		}
+		guesses = dict.Words
 	}
-	gowordle.RECURSIVE = recursive
-	if recursive {
+	words := gowordle.LoadWordLists(answers, flags.Count, guesses)
+	var unpruned *gowordle.WordLists
+	history := gowordle.DefaultAnswerHistory()
+	if flags.History != "" {
+		var err error
+		if history, err = gowordle.LoadAnswerHistoryFile(flags.History); err != nil {
+			log.Fatal(err)
+		}
+	}
+	puzzle := puzzleNumber(flags)
+	if puzzle != noPuzzle {
+		unpruned = words
+		remaining := gowordle.ExcludeUsed(gowordle.WordleWordsToStrings(words.Answers), history.UsedBefore(puzzle))
+		if len(remaining) == 0 {
+			log.Fatalf("every answer was used before puzzle %d", puzzle)
+		}
+		words = gowordle.NewWordLists(remaining, gowordle.WordleWordsToStrings(words.Guesses))
+	}
+	gowordle.RECURSIVE = flags.Recursive
+	if flags.Recursive {
 		gowordle.BestGuess1 = gowordle.ScoreAlgorithmRecursive
 	}
+	firstWord := flags.FirstWord
 	if firstWord == "" {
 		firstWord = "raise"
 	}
+	if _, err := gowordle.ParseGuess(firstWord); err != nil {
+		log.Fatalf("--first: %v", err)
+	}
 	return GlobalConfiguration{
-		AllWords:  gowordle.SortedWordleDictionary()[0:count],
-		Recursive: recursive,
-		progress:  progress,
+		Answers:   gowordle.WordleWordsToStrings(words.Answers),
+		Words:     words,
+		Unpruned:  unpruned,
+		History:   history,
+		Puzzle:    puzzle,
+		Recursive: flags.Recursive,
+		progress:  flags.Progress,
 		FirstWord: firstWord,
+		Output:    flags.out,
+		events:    flags.events,
+		Stats:     flags.Stats,
 	}
 }
 
+// noPuzzle is the --puzzle default, puzzle 0 is the first puzzle
+const noPuzzle = -1
+
+// puzzleNumber is --puzzle or the puzzle for --date, noPuzzle if neither was provided
+func puzzleNumber(flags GlobalFlags) int {
+	if flags.Date == "" {
+		if flags.Puzzle < noPuzzle {
+			log.Fatalf("--puzzle %d is not a puzzle number", flags.Puzzle)
+		}
+		return flags.Puzzle
+	}
+	if flags.Puzzle != noPuzzle {
+		log.Fatal("only one of --puzzle and --date can be provided")
+	}
+	puzzle, err := gowordle.ParsePuzzleDate(flags.Date)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return puzzle
+}
+
 func main() {
-	count := 0
-	recursive := false
-	progress := false
-	firstWord := ""
+	flags := GlobalFlags{}
+	notation := ""
+	absurdle := false
+	solve := false
+	maxGuesses := 6
+	width := 20
+	boards := 1
+	games := 100
+	var seed int64 = 0
+	simMaxGuesses := 0
+	simOpts := simOptions{}
+	gameSeed := int64(0)
+	gameMaxGuesses := gowordle.DefaultMaxGuesses
+	paletteName := "default"
+	measureOpts := measureOptions{}
+	pruneOpts := memoPruneOptions{}
+	hintLevel := 1
+	serveAddr := "localhost:8080"
+	hostOpts := hostOptions{}
+	engineStrategy := ""
+	arenaOpts := arenaOptions{}
+	record := false
+	statsOpts := statsOptions{}
 	// going raise blunt
-	//server(globalCofiguration(count, recursive, progress, firstWord), "going", []string{"raise", "blunt"})
-	// FirstWords(globalCofiguration(count, recursive, progress, firstWord))
-	// playWordle(globalCofiguration(count, true, progress, firstWord), []string{"raise", "ryyry"})
-	// simulate(globalCofiguration(count, true, progress, firstWord), []string{})
+	//server(globalCofiguration(flags), "going", []string{"raise", "blunt"})
+	// FirstWords(globalCofiguration(flags))
+	// playWordle(globalCofiguration(flags), []string{"raise", "ryyry"})
+	// simulate(globalCofiguration(flags), []string{})
 	cmd := &cli.Command{
 		Name:  "wdl",
 		Usage: "wordle",
@@ -204,43 +738,165 @@ func main() {
 				Value:       0,
 				Aliases:     []string{"c"},
 				Usage:       "number of words, 0 is all words",
-				Destination: &count,
+				Destination: &flags.Count,
 			},
 			&cli.BoolFlag{
 				Name:        "recursive",
 				Value:       false,
 				Aliases:     []string{"r"},
 				Usage:       "turn on recursive flag slower but better",
-				Destination: &recursive,
-			},
-			&cli.BoolFlag{
-				Name:        "progress",
-				Value:       false,
-				Aliases:     []string{"p"},
-				Usage:       "show progress bar",
-				Destination: &progress,
+				Destination: &flags.Recursive,
 			},
 			&cli.BoolFlag{
 				Name:        "progress",
 				Value:       false,
 				Aliases:     []string{"p"},
 				Usage:       "show progress bar",
-				Destination: &progress,
+				Destination: &flags.Progress,
 			},
 			&cli.StringFlag{
 				Name:        "first",
 				Value:       "",
 				Aliases:     []string{"f"},
 				Usage:       "first word to guess, default is 'raise', only used with sim command",
-				Destination: &firstWord,
+				Destination: &flags.FirstWord,
+			},
+			&cli.StringFlag{
+				Name:        "notation",
+				Value:       "",
+				Aliases:     []string{"n"},
+				Usage:       "answer colors notation: gyr, gyb, gyx, dots, dashes, digits, emoji or three symbols for green, yellow and red like '+?-'",
+				Destination: &notation,
+			},
+			&cli.StringFlag{
+				Name:        "dict",
+				Value:       "",
+				Usage:       "file of answers to use instead of the built in answers: text one word per line, .csv word,weight or .json, - is stdin",
+				Destination: &flags.Dict,
+			},
+			&cli.StringFlag{
+				Name:        "guesses",
+				Value:       "",
+				Usage:       "file of allowed guesses added to the answers and the built in guesses, same formats as --dict",
+				Destination: &flags.Guesses,
+			},
+			&cli.StringFlag{
+				Name:        "history",
+				Value:       "",
+				Usage:       "answer history file used with --puzzle and --date: text one answer per line starting with puzzle 0 or .csv puzzle,answer. Default is the original answer order",
+				Destination: &flags.History,
+			},
+			&cli.IntFlag{
+				Name:        "puzzle",
+				Value:       noPuzzle,
+				Usage:       "remove the answers used by the puzzles before this puzzle number, the first puzzle is 0",
+				Destination: &flags.Puzzle,
+			},
+			&cli.StringFlag{
+				Name:        "date",
+				Value:       "",
+				Usage:       "remove the answers used by the puzzles before the puzzle on this date, YYYY-MM-DD",
+				Destination: &flags.Date,
+			},
+			&cli.StringFlag{
+				Name:        "output",
+				Value:       outputText,
+				Aliases:     []string{"o"},
+				Usage:       "text, or records for scripts: json, jsonl (one record per line) or csv",
+				Destination: &flags.Output,
+			},
+			&cli.StringFlag{
+				Name:        "memo",
+				Value:       "",
+				Usage:       "solver memo file, remembered results are loaded from it and new ones are appended so later runs are faster",
+				Destination: &flags.Memo,
+			},
+			&cli.StringFlag{
+				Name:        "config",
+				Value:       "",
+				Usage:       "config file to use instead of the user config, <user config dir>/wdl/config.yaml, and the project config, the closest .wdl.yaml",
+				Destination: &flags.Config,
+			},
+			&cli.StringFlag{
+				Name:        "profile",
+				Value:       "",
+				Usage:       "profile from the config files applied over their settings",
+				Destination: &flags.Profile,
+			},
+			&cli.StringFlag{
+				Name:        "events",
+				Value:       "",
+				Usage:       "progress events of sim, measure and first: stderr for JSON lines or an address like localhost:8081 for server-sent events on /events, POST /cancel stops the run",
+				Destination: &flags.Events,
+			},
+			&cli.StringFlag{
+				Name:        "stats",
+				Value:       "",
+				Usage:       "file of recorded games for the stats command and play --record, default is <user config dir>/wdl/stats.jsonl",
+				Destination: &flags.Stats,
 			},
 		},
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			config, err := loadConfig(flags.Config, flags.Profile)
+			if err != nil {
+				return ctx, cli.Exit(err.Error(), 1)
+			}
+			flags.config = config
+			configure(cmd, "count", config.Count, &flags.Count)
+			configure(cmd, "recursive", config.Recursive, &flags.Recursive)
+			configure(cmd, "progress", config.Progress, &flags.Progress)
+			configure(cmd, "first", config.First, &flags.FirstWord)
+			configure(cmd, "notation", config.Notation, &notation)
+			configure(cmd, "output", config.Output, &flags.Output)
+			configure(cmd, "dict", config.Dict, &flags.Dict)
+			configure(cmd, "guesses", config.Guesses, &flags.Guesses)
+			configure(cmd, "history", config.History, &flags.History)
+			configure(cmd, "memo", config.Memo, &flags.Memo)
+			configure(cmd, "events", config.Events, &flags.Events)
+			configure(cmd, "stats", config.Stats, &flags.Stats)
+			out, err := newOutput(flags.Output, os.Stdout)
+			if err != nil {
+				return ctx, cli.Exit(err.Error(), 1)
+			}
+			flags.out = out
+			ctx, cancel := context.WithCancel(ctx)
+			if flags.events, err = newEvents(flags.Events, cancel); err != nil {
+				cancel()
+				return ctx, cli.Exit(err.Error(), 1)
+			}
+			if flags.Memo != "" {
+				if err := gowordle.OpenMemoFile(flags.Memo); err != nil {
+					return ctx, cli.Exit(err.Error(), 1)
+				}
+			}
+			if notation == "" {
+				return ctx, nil
+			}
+			if err := gowordle.DefaultFeedback.SetNotation(notation); err != nil {
+				return ctx, cli.Exit(err.Error(), 1)
+			}
+			return ctx, nil
+		},
+		After: func(ctx context.Context, cmd *cli.Command) error {
+			if err := flags.events.Close(); err != nil {
+				return err
+			}
+			if err := gowordle.SolverMemo.Close(); err != nil {
+				return err
+			}
+			if flags.out == nil {
+				return nil
+			}
+			return flags.out.Close()
+		},
 		Commands: []*cli.Command{
 			{
 				Name:  "first",
 				Usage: "first guess",
-				Action: func(context.Context, *cli.Command) error {
-					FirstWords(globalCofiguration(count, recursive, progress, firstWord))
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					if err := FirstWords(ctx, globalCofiguration(flags)); err != nil {
+						return cli.Exit(err.Error(), 1)
+					}
 					return nil
 				},
 			},
@@ -249,8 +905,7 @@ func main() {
 				Aliases: []string{"fc"},
 				Usage:   "firstbycolor",
 				Action: func(context.Context, *cli.Command) error {
-					FirstWordsByAnswerColor(globalCofiguration(count, recursive, progress, firstWord))
-					return nil
+					return FirstWordsByAnswerColor(globalCofiguration(flags))
 				},
 			},
 			{
@@ -258,27 +913,142 @@ func main() {
 				Usage: `sim [answer] ...
 				Simulate multiple games by specifying a list of answers for each game.  If no answers are provided,
 				simulate all words.  All words can be cut back by using the -count flag.
+				With --boards N each game has N boards (dordle, quordle, octordle), answers are taken N at a time.
+				If no answers are provided --games random groups of N answers are simulated.
+				Games are played --workers at a time, with --checkpoint each game is saved as it finishes and running
+				the same command again resumes, the --boards games are not checkpointed.  With --puzzle or --date the
+				games played without pruning to compare are saved to the checkpoint file with .unpruned added.
 				`,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:        "boards",
+						Value:       1,
+						Aliases:     []string{"b"},
+						Usage:       "number of boards played at once",
+						Destination: &boards,
+					},
+					&cli.IntFlag{
+						Name:        "games",
+						Value:       100,
+						Aliases:     []string{"g"},
+						Usage:       "with --boards the number of random games",
+						Destination: &games,
+					},
+					&cli.IntFlag{
+						Name:        "max",
+						Value:       0,
+						Aliases:     []string{"m"},
+						Usage:       "maximum number of guesses in a game, 0 is 6 or boards+5 with --boards",
+						Destination: &simMaxGuesses,
+					},
+					&cli.Int64Flag{
+						Name:        "seed",
+						Value:       0,
+						Usage:       "with --boards the random seed, 0 is seeded from the time",
+						Destination: &seed,
+					},
+					&cli.IntFlag{
+						Name:        "workers",
+						Value:       runtime.NumCPU(),
+						Aliases:     []string{"w"},
+						Usage:       "games played at once",
+						Destination: &simOpts.Workers,
+					},
+					&cli.StringFlag{
+						Name:        "checkpoint",
+						Value:       "",
+						Usage:       "file the games are written to as they finish, a run that was stopped resumes from it skipping the finished answers",
+						Destination: &simOpts.Checkpoint,
+					},
+				},
 				Action: func(ctx context.Context, cmd *cli.Command) error {
-					globalCofiguration(count, recursive, progress, firstWord)
-					if cmd.NArg() == 0 {
-						simulate(globalCofiguration(count, recursive, progress, firstWord), []string{})
-					} else {
-						simulate(globalCofiguration(count, recursive, progress, firstWord), cmd.Args().Slice())
+					configure(cmd, "workers", flags.config.Workers, &simOpts.Workers)
+					if boards > 1 {
+						if cmd.NArg()%boards != 0 {
+							return cli.Exit(fmt.Sprintf("answers must be in groups of %d", boards), 1)
+						}
+						if simMaxGuesses == 0 {
+							simMaxGuesses = gowordle.MultiBoardMaxGuesses(boards)
+						}
+						return simulateMultiBoard(globalCofiguration(flags), boards, games, seed, simMaxGuesses, cmd.Args().Slice())
+					}
+					if simMaxGuesses == 0 {
+						simMaxGuesses = gowordle.DefaultMaxGuesses
+					}
+					if err := simulate(ctx, globalCofiguration(flags), simMaxGuesses, cmd.Args().Slice(), simOpts); err != nil {
+						return cli.Exit(err.Error(), 1)
 					}
 					return nil
 				},
 			},
 			{
-				Name:  "play",
-				Usage: "play a game of wordle by entering pairs of [guess answer]...",
+				Name: "play",
+				Usage: `play a game of wordle by entering pairs of [guess answer]...
+				answer colors can be gyrrr, gybbb, gyxxx, gy..., gy---, 21000, 🟩🟨⬛⬛⬛ or the --notation symbols.
+				With --record a game that is over is added to the --stats file`,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:        "record",
+						Value:       false,
+						Usage:       "record the game for the stats command once the last answer is ggggg or after 6 guesses",
+						Destination: &record,
+					},
+				},
 				Action: func(ctx context.Context, cmd *cli.Command) error {
 					if cmd.NArg()%2 != 0 {
 						return cli.Exit("must have pairs of guess answer", 1)
 					} else if cmd.NArg() < 2 {
 						return cli.Exit("must have at least one guess answer", 2)
-					} else {
-						playWordle(globalCofiguration(count, recursive, progress, firstWord), cmd.Args().Slice())
+					} else if err := playWordle(globalCofiguration(flags), cmd.Args().Slice(), record); err != nil {
+						return cli.Exit(err.Error(), 1)
+					}
+					return nil
+				},
+			},
+			{
+				Name: "game",
+				Usage: `play a game against the computer, the answer is random or the answer for --puzzle or --date from the --history.
+				Type a guess each turn, the game ends with a share grid`,
+				Flags: []cli.Flag{
+					&cli.Int64Flag{
+						Name:        "seed",
+						Value:       0,
+						Usage:       "random seed for the answer, 0 is seeded from the time",
+						Destination: &gameSeed,
+					},
+					&cli.IntFlag{
+						Name:        "max",
+						Value:       gowordle.DefaultMaxGuesses,
+						Aliases:     []string{"m"},
+						Usage:       "number of guesses allowed",
+						Destination: &gameMaxGuesses,
+					},
+					&cli.StringFlag{
+						Name:        "palette",
+						Value:       "default",
+						Usage:       "tile colors: default, contrast (orange and blue) or symbols ([A] green, (A) yellow, no color)",
+						Destination: &paletteName,
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					if !flags.out.Text() {
+						return cli.Exit("game only supports --output text", 1)
+					}
+					if err := playGame(globalCofiguration(flags), os.Stdin, gameSeed, gameMaxGuesses, paletteName); err != nil {
+						return cli.Exit(err.Error(), 1)
+					}
+					return nil
+				},
+			},
+			{
+				Name:  "interactive",
+				Usage: "play along with a live game, enter a guess and its answer colors each turn. help lists the other commands",
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					if !flags.out.Text() {
+						return cli.Exit("interactive only supports --output text", 1)
+					}
+					if err := interactive(globalCofiguration(flags), os.Stdin); err != nil {
+						return cli.Exit(err.Error(), 1)
 					}
 					return nil
 				},
@@ -286,36 +1056,530 @@ func main() {
 			{
 				Name: "server",
 				Usage: `server solution guess...
-				be a wordle server return the ryg for each guess along with the remaining words`,
+				be a wordle server return the ryg for each guess along with the remaining words.
+				With --absurdle there is no solution, only guesses, the server keeps the most words alive.
+				With --absurdle --solve find the fewest guesses that are guaranteed to win against the absurdle server`,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:        "absurdle",
+						Aliases:     []string{"a"},
+						Usage:       "adversarial server that never commits to a solution",
+						Destination: &absurdle,
+					},
+					&cli.BoolFlag{
+						Name:        "solve",
+						Usage:       "with --absurdle find the fewest guesses that are guaranteed to win",
+						Destination: &solve,
+					},
+					&cli.IntFlag{
+						Name:        "max",
+						Value:       6,
+						Usage:       "with --solve the most guesses to try",
+						Destination: &maxGuesses,
+					},
+					&cli.IntFlag{
+						Name:        "width",
+						Value:       20,
+						Usage:       "with --solve the number of guesses tried at each turn, the result is an upper bound, 0 is all words (slow) for the fewest",
+						Destination: &width,
+					},
+				},
 				Action: func(ctx context.Context, cmd *cli.Command) error {
+					if absurdle {
+						if solve {
+							if !absurdleSolve(globalCofiguration(flags), maxGuesses, width) {
+								if err := flags.out.Close(); err != nil {
+									return cli.Exit(err.Error(), 1)
+								}
+								return cli.Exit("", 4)
+							}
+							return nil
+						}
+						if cmd.NArg() < 1 {
+							return cli.Exit("must supply one or more guesses", 3)
+						}
+						if err := absurdleServer(globalCofiguration(flags), cmd.Args().Slice(), false); err != nil {
+							return cli.Exit(err.Error(), 1)
+						}
+						return nil
+					}
 					if cmd.NArg() < 2 {
 						return cli.Exit("must supply both an answer and one or more guesses", 3)
 					}
 					args := cmd.Args().Slice()
-					server(globalCofiguration(count, recursive, progress, firstWord), args[0], args[1:])
+					if err := server(globalCofiguration(flags), args[0], args[1:]); err != nil {
+						return cli.Exit(err.Error(), 1)
+					}
 					return nil
 				},
 			},
 			{
-				Name:  "measure",
-				Usage: "measure the performance of an algorithm by playing against a set of answers",
+				Name: "serve",
+				Usage: `serve a local HTTP JSON API for a web frontend, POST a JSON request to
+				/filter, /suggest, /partition, /feedback or /simulate.  The word lists and solver caches stay in memory`,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:        "addr",
+						Value:       "localhost:8080",
+						Usage:       "address to listen on",
+						Destination: &serveAddr,
+					},
+				},
 				Action: func(ctx context.Context, cmd *cli.Command) error {
-					playWordle(globalCofiguration(count, recursive, progress, firstWord), cmd.Args().Slice())
+					if !flags.out.Text() {
+						return cli.Exit("serve only supports --output text, the responses are always JSON", 1)
+					}
+					if err := serve(globalCofiguration(flags), serveAddr); err != nil {
+						return cli.Exit(err.Error(), 1)
+					}
 					return nil
 				},
 			},
+			{
+				Name: "host",
+				Usage: `host games of wordle for many players over HTTP: POST /games to create a game with a random, seeded
+				or daily answer, GET /games/{id} for its state and POST /games/{id}/guesses to guess.
+				Games that are not used for the --timeout are removed, with --state they survive a restart`,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:        "addr",
+						Value:       "localhost:8080",
+						Usage:       "address to listen on",
+						Destination: &hostOpts.Addr,
+					},
+					&cli.DurationFlag{
+						Name:        "timeout",
+						Value:       24 * time.Hour,
+						Usage:       "remove a game that is not used for this long",
+						Destination: &hostOpts.Timeout,
+					},
+					&cli.StringFlag{
+						Name:        "state",
+						Usage:       "file to keep the games in, each game and guess is appended and the games are read back on start",
+						Destination: &hostOpts.State,
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					if !flags.out.Text() {
+						return cli.Exit("host only supports --output text, the responses are always JSON", 1)
+					}
+					if err := host(globalCofiguration(flags), hostOpts); err != nil {
+						return cli.Exit(err.Error(), 1)
+					}
+					return nil
+				},
+			},
+			{
+				Name: "measure",
+				Usage: `measure [answer]...
+				benchmark strategies by simulating a game for each answer: average guesses with a 95% confidence interval,
+				the guess distribution, failures, the worst answers, time and memory.  With more than one strategy
+				the answers where they disagree are listed side by side.  All the answers are used if none are provided`,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:        "strategy",
+						Value:       "",
+						Aliases:     []string{"s"},
+						Usage:       "comma separated strategies: " + strategyNames() + ", default is the first or recursive with --recursive",
+						Destination: &measureOpts.Strategies,
+					},
+					&cli.StringFlag{
+						Name:        "answers",
+						Value:       "",
+						Usage:       "file of answers to play, same formats as --dict",
+						Destination: &measureOpts.AnswersFile,
+					},
+					&cli.IntFlag{
+						Name:        "sample",
+						Value:       0,
+						Usage:       "play a random sample of this many answers, 0 is all of them",
+						Destination: &measureOpts.Sample,
+					},
+					&cli.Int64Flag{
+						Name:        "seed",
+						Value:       0,
+						Usage:       "with --sample the random seed, 0 is seeded from the time",
+						Destination: &measureOpts.Seed,
+					},
+					&cli.IntFlag{
+						Name:        "workers",
+						Value:       runtime.NumCPU(),
+						Aliases:     []string{"w"},
+						Usage:       "games played at once",
+						Destination: &measureOpts.Workers,
+					},
+					&cli.IntFlag{
+						Name:        "worst",
+						Value:       10,
+						Usage:       "number of worst answers shown",
+						Destination: &measureOpts.Worst,
+					},
+					&cli.IntFlag{
+						Name:        "max",
+						Value:       gowordle.DefaultMaxGuesses,
+						Aliases:     []string{"m"},
+						Usage:       "maximum number of guesses in a game",
+						Destination: &measureOpts.MaxGuesses,
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					configure(cmd, "workers", flags.config.Workers, &measureOpts.Workers)
+					configure(cmd, "strategy", flags.config.Strategy, &measureOpts.Strategies)
+					if err := measure(ctx, globalCofiguration(flags), measureOpts, cmd.Args().Slice()); err != nil {
+						return cli.Exit(err.Error(), 1)
+					}
+					return nil
+				},
+			},
+			{
+				Name: "engine",
+				Usage: `engine
+				play with the engine protocol on stdin and stdout so other programs can drive wdl, like UCI for chess:
+				wdl, isready, dict answers|guesses word..., newgame, guess word feedback, go [milliseconds] and quit`,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:        "strategy",
+						Value:       "",
+						Aliases:     []string{"s"},
+						Usage:       "strategy: " + strategyNames() + ", default is the first or recursive with --recursive",
+						Destination: &engineStrategy,
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					if !flags.out.Text() {
+						return cli.Exit("engine only supports --output text, it speaks the engine protocol", 1)
+					}
+					configure(cmd, "strategy", flags.config.Strategy, &engineStrategy)
+					if err := engine(globalCofiguration(flags), engineStrategy); err != nil {
+						return cli.Exit(err.Error(), 1)
+					}
+					return nil
+				},
+			},
+			{
+				Name: "arena",
+				Usage: `arena --engine command... [answer]...
+				run engine processes that speak the engine protocol, see wdl engine, over the same answers and compare
+				them like measure.  Each engine is sent the answers and guesses, --workers copies of each engine play at once`,
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{
+						Name:        "engine",
+						Aliases:     []string{"e"},
+						Usage:       `engine command line, repeat for each engine: --engine "wdl engine -s recursive" --engine "python3 bot.py"`,
+						Destination: &arenaOpts.Engines,
+					},
+					&cli.DurationFlag{
+						Name:        "movetime",
+						Value:       0,
+						Usage:       "time an engine has for each guess, sent with go, 0 is no limit",
+						Destination: &arenaOpts.MoveTime,
+					},
+					&cli.StringFlag{
+						Name:        "answers",
+						Value:       "",
+						Usage:       "file of answers to play, same formats as --dict",
+						Destination: &arenaOpts.AnswersFile,
+					},
+					&cli.IntFlag{
+						Name:        "sample",
+						Value:       0,
+						Usage:       "play a random sample of this many answers, 0 is all of them",
+						Destination: &arenaOpts.Sample,
+					},
+					&cli.Int64Flag{
+						Name:        "seed",
+						Value:       0,
+						Usage:       "with --sample the random seed, 0 is seeded from the time",
+						Destination: &arenaOpts.Seed,
+					},
+					&cli.IntFlag{
+						Name:        "workers",
+						Value:       runtime.NumCPU(),
+						Aliases:     []string{"w"},
+						Usage:       "copies of each engine playing at once",
+						Destination: &arenaOpts.Workers,
+					},
+					&cli.IntFlag{
+						Name:        "worst",
+						Value:       10,
+						Usage:       "number of worst answers shown",
+						Destination: &arenaOpts.Worst,
+					},
+					&cli.IntFlag{
+						Name:        "max",
+						Value:       gowordle.DefaultMaxGuesses,
+						Aliases:     []string{"m"},
+						Usage:       "maximum number of guesses in a game",
+						Destination: &arenaOpts.MaxGuesses,
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					configure(cmd, "workers", flags.config.Workers, &arenaOpts.Workers)
+					if err := arena(globalCofiguration(flags), arenaOpts, cmd.Args().Slice()); err != nil {
+						return cli.Exit(err.Error(), 1)
+					}
+					return nil
+				},
+			},
+			{
+				Name: "analyze",
+				Usage: `analyze solution guess...
+				replay a game, for each guess show the remaining words, the strategy's guess, the expected guesses
+				for both along with skill and luck ratings`,
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					if cmd.NArg() < 2 {
+						return cli.Exit("must supply both a solution and one or more guesses", 3)
+					}
+					args := cmd.Args().Slice()
+					if err := analyze(globalCofiguration(flags), args[0], args[1:]); err != nil {
+						return cli.Exit(err.Error(), 1)
+					}
+					return nil
+				},
+			},
+			{
+				Name: "reverse",
+				Usage: `reverse answer [share grid]
+				list the words that could have been guessed for each row of a share grid, "Wordle 812 4/6 🟩🟨⬛...".
+				The share grid is read from stdin if not provided`,
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					if cmd.NArg() < 1 {
+						return cli.Exit("must supply the answer", 3)
+					}
+					args := cmd.Args().Slice()
+					if err := reverse(globalCofiguration(flags), args[0], args[1:]); err != nil {
+						return cli.Exit(err.Error(), 1)
+					}
+					return nil
+				},
+			},
+			{
+				Name: "hint",
+				Usage: `hint [guess answer]...
+				help without giving away the best guess, each level adds to the one below:
+				1 the number of possible answers, 2 the letters and spot that best split them, 3 repeated letters, 4 the best guess,
+				before any guesses the configured first guess`,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:        "level",
+						Value:       1,
+						Aliases:     []string{"l"},
+						Usage:       fmt.Sprintf("hint level 1 to %d", gowordle.MaxHintLevel),
+						Destination: &hintLevel,
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					if cmd.NArg()%2 != 0 {
+						return cli.Exit("must have pairs of guess answer", 1)
+					} else if err := hint(globalCofiguration(flags), hintLevel, cmd.Args().Slice()); err != nil {
+						return cli.Exit(err.Error(), 1)
+					}
+					return nil
+				},
+			},
+			{
+				Name: "why",
+				Usage: `why word [guess answer]...
+				explain if the word is still a possible answer after the guess answer pairs or the rule that ruled it out:
+				green mismatch, too few copies, red letter present, too many copies, yellow in the same spot or red in the same spot.
+				Every pair that ruled out the word is named, pairs that contradict each other are explained too.`,
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					if cmd.NArg() < 1 {
+						return cli.Exit("must supply the word", 3)
+					} else if cmd.NArg()%2 != 1 {
+						return cli.Exit("must have pairs of guess answer", 1)
+					}
+					args := cmd.Args().Slice()
+					if err := why(globalCofiguration(flags), args[0], args[1:]); err != nil {
+						return cli.Exit(err.Error(), 1)
+					}
+					return nil
+				},
+			},
+			{
+				Name:  "dict",
+				Usage: "dictionary tools, a dictionary is a file, - for stdin or builtin:answers, builtin:answers_orig, builtin:guesses",
+				Commands: []*cli.Command{
+					{
+						Name: "audit",
+						Usage: `audit [dictionary]...
+						report duplicates, words that are not 5 letters, non-ASCII runes, upper case and unusual letter patterns.
+						The built in dictionaries are audited if none are provided`,
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							clean, err := dictAudit(flags.out, cmd.Args().Slice())
+							if err != nil {
+								return cli.Exit(err.Error(), 1)
+							}
+							if !clean {
+								if err := flags.out.Close(); err != nil {
+									return cli.Exit(err.Error(), 1)
+								}
+								return cli.Exit("", 4)
+							}
+							return nil
+						},
+					},
+					{
+						Name: "diff",
+						Usage: `diff a b
+						show the answers added and removed going from a to b and how each change moves the best first guess`,
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							if cmd.NArg() != 2 {
+								return cli.Exit("must supply two dictionaries", 3)
+							}
+							if err := dictDiff(globalCofiguration(flags), cmd.Args().Get(0), cmd.Args().Get(1)); err != nil {
+								return cli.Exit(err.Error(), 1)
+							}
+							return nil
+						},
+					},
+				},
+			},
+			{
+				Name: "memo",
+				Usage: `inspect and prune the --memo file of remembered solver results.  Entries are keyed by kind, the
+				guess list and the possible words so one file can be shared by different dictionaries`,
+				Commands: []*cli.Command{
+					{
+						Name:  "stats",
+						Usage: "number of entries of each kind for each guess list",
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							if err := memoStats(globalCofiguration(flags)); err != nil {
+								return cli.Exit(err.Error(), 1)
+							}
+							return nil
+						},
+					},
+					{
+						Name:  "prune",
+						Usage: "remove entries and rewrite the memo file, also drops duplicate and partial lines",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:        "kind",
+								Value:       "",
+								Usage:       "remove the entries of this kind: " + gowordle.MemoRecursive + ", " + gowordle.MemoMatches + " or " + gowordle.MemoRanking,
+								Destination: &pruneOpts.Kind,
+							},
+							&cli.BoolFlag{
+								Name:        "stale",
+								Usage:       "remove the entries for other guess lists, including the answers strategy which guesses from the possible words",
+								Destination: &pruneOpts.Stale,
+							},
+							&cli.IntFlag{
+								Name:        "smaller",
+								Value:       0,
+								Usage:       "remove the entries for fewer possible words than this, they are quick to work out again",
+								Destination: &pruneOpts.Smaller,
+							},
+						},
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							if err := memoPrune(globalCofiguration(flags), pruneOpts); err != nil {
+								return cli.Exit(err.Error(), 1)
+							}
+							return nil
+						},
+					},
+				},
+			},
+			{
+				Name: "stats",
+				Usage: `your statistics from the --stats file: games played, win percentage, streaks and the guess distribution.
+				The games you won are compared with the solver's games for the same answers.  Games are added with
+				play --record or stats add`,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:        "last",
+						Value:       10,
+						Usage:       "number of recent games listed",
+						Destination: &statsOpts.Last,
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					if err := statsShow(globalCofiguration(flags), statsOpts); err != nil {
+						return cli.Exit(err.Error(), 1)
+					}
+					return nil
+				},
+				Commands: []*cli.Command{
+					{
+						Name:  "add",
+						Usage: "add answer guess... record a game by hand, the last guess is the answer or there are 6 guesses",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:        "date",
+								Value:       "",
+								Usage:       "day the game was played, YYYY-MM-DD, default is today",
+								Destination: &statsOpts.Date,
+							},
+						},
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							if cmd.NArg() < 2 {
+								return cli.Exit("must have the answer and at least one guess", 2)
+							}
+							if err := statsAdd(globalCofiguration(flags), statsOpts, cmd.Args().First(), cmd.Args().Tail()); err != nil {
+								return cli.Exit(err.Error(), 1)
+							}
+							return nil
+						},
+					},
+					{
+						Name:  "export",
+						Usage: "export [file] write the recorded games as JSON lines for a backup, stdout if no file is provided",
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							if err := statsExport(globalCofiguration(flags), cmd.Args().First()); err != nil {
+								return cli.Exit(err.Error(), 1)
+							}
+							return nil
+						},
+					},
+					{
+						Name:  "import",
+						Usage: "import file... add the games from exported files, games already recorded are skipped",
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							if cmd.NArg() < 1 {
+								return cli.Exit("must have at least one file", 2)
+							}
+							if err := statsImport(globalCofiguration(flags), cmd.Args().Slice()); err != nil {
+								return cli.Exit(err.Error(), 1)
+							}
+							return nil
+						},
+					},
+				},
+			},
+			{
+				Name:  "config",
+				Usage: "config files, see --config and --profile",
+				Commands: []*cli.Command{
+					{
+						Name:  "show",
+						Usage: "show the settings after the config files, the profile and the flags are merged",
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							if err := configShow(globalCofiguration(flags), flags, notation); err != nil {
+								return cli.Exit(err.Error(), 1)
+							}
+							return nil
+						},
+					},
+				},
+			},
 			{
 				Name:  "cache",
 				Usage: "build a cache[guess][solution] = Answer",
 				Action: func(ctx context.Context, cmd *cli.Command) error {
-					cache(globalCofiguration(count, recursive, progress, firstWord))
-					return nil
+					return cache(globalCofiguration(flags))
 				},
 			},
 		},
 	}
 
-	if err := cmd.Run(context.Background(), os.Args); err != nil {
+	// the first interrupt cancels the run so it can stop cleanly, the second one kills it
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	if err := cmd.Run(ctx, os.Args); err != nil {
 		log.Fatal(err)
 	}
 }
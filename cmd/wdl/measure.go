@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/powellquiring/gowordle/gowordle"
+	"github.com/schollz/progressbar/v3"
+)
+
+// measureOptions are the measure command flags
+type measureOptions struct {
+	Strategies  string // comma separated
+	AnswersFile string
+	Sample      int
+	Seed        int64
+	Workers     int
+	Worst       int
+	MaxGuesses  int
+}
+
+// strategyNames for the usage
+func strategyNames() string {
+	names := []string{}
+	for _, strategy := range gowordle.Strategies {
+		names = append(names, strategy.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// measureAnswers are the answers provided, or the answers in the file, or all the answers.  With a sample a random
+// sample of them.
+func measureAnswers(globalConfig GlobalConfiguration, options measureOptions, args []string) ([]string, error) {
+	answers := args
+	if len(answers) == 0 && options.AnswersFile != "" {
+		dict, err := gowordle.LoadDictionaryFile(options.AnswersFile)
+		if err != nil {
+			return nil, err
+		}
+		answers = dict.Words
+	}
+	if len(answers) == 0 {
+		answers = globalConfig.Answers
+	}
+	for _, answer := range answers {
+		if !slices.Contains(globalConfig.Answers, answer) {
+			return nil, fmt.Errorf("%s is not in the answer list", answer)
+		}
+	}
+	if options.Sample > 0 && options.Sample < len(answers) {
+		seed := options.Seed
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+		}
+		r := rand.New(rand.NewSource(seed))
+		sample := make([]string, 0, options.Sample)
+		for _, index := range r.Perm(len(answers))[:options.Sample] {
+			sample = append(sample, answers[index])
+		}
+		answers = sample
+	}
+	return answers, nil
+}
+
+// measure benchmarks each strategy over the same answers and compares them
+func measure(ctx context.Context, globalConfig GlobalConfiguration, options measureOptions, args []string) error {
+	names := strings.Split(options.Strategies, ",")
+	if options.Strategies == "" {
+		names = []string{defaultStrategy(globalConfig)}
+	}
+	strategies := []gowordle.Strategy{}
+	for _, name := range names {
+		strategy, err := gowordle.FindStrategy(strings.TrimSpace(name))
+		if err != nil {
+			return err
+		}
+		strategies = append(strategies, strategy)
+	}
+	answers, err := measureAnswers(globalConfig, options, args)
+	if err != nil {
+		return err
+	}
+	results := []gowordle.BenchmarkResult{}
+	for _, strategy := range strategies {
+		var bar *progressbar.ProgressBar
+		if globalConfig.progress {
+			bar = progressbar.Default(int64(len(answers)), strategy.Name)
+		} else {
+			bar = progressbar.DefaultSilent(int64(len(answers)))
+		}
+		progress := globalConfig.events.progress("measure "+strategy.Name, len(answers))
+		played := 0
+		done := func(game gowordle.BenchmarkGame) {
+			bar.Add(1)
+			progress.Game(game.SimulateResult)
+			played++
+		}
+		result, err := gowordle.RunBenchmarkContext(ctx, globalConfig.Words, strategy, answers, globalConfig.FirstWord, options.MaxGuesses, options.Workers, done)
+		finish(ctx, progress)
+		if err != nil {
+			return fmt.Errorf("measure %s cancelled after %d of %d games: %w", strategy.Name, played, len(answers), err)
+		}
+		results = append(results, result)
+		if !globalConfig.Output.Text() {
+			for _, game := range result.Games {
+				record := measureRecord{result.Strategy, game.Answer, game.Guesses, game.Colors, len(game.Guesses), game.Solved, game.Duration.Microseconds()}
+				if err := globalConfig.Output.Write(record); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		printBenchmark(result, options)
+	}
+	if globalConfig.Output.Text() && len(results) > 1 {
+		printDisagreements(results)
+	}
+	return nil
+}
+
+func printBenchmark(result gowordle.BenchmarkResult, options measureOptions) {
+	average, interval := result.Average()
+	failed := result.Failed()
+	fmt.Printf("%s: %d games, average %.3f ± %.3f (95%%), failed %d (%.2f%%) with %d guesses\n", result.Strategy, len(result.Games),
+		average, interval, len(failed), 100*float64(len(failed))/float64(len(result.Games)), options.MaxGuesses)
+	distribution := result.Distribution()
+	turns := make([]int, 0, len(distribution))
+	for turn := range distribution {
+		turns = append(turns, turn)
+	}
+	sort.Ints(turns)
+	fmt.Print("  guesses:")
+	for _, turn := range turns {
+		fmt.Printf(" %d:%d", turn, distribution[turn])
+	}
+	fmt.Println()
+	fmt.Printf("  time per game %v, total %v with %d workers", result.TimePerGame().Round(time.Microsecond),
+		result.Duration.Round(time.Millisecond), options.Workers)
+	if result.Heap > 0 { // not measured for engines in other processes
+		fmt.Printf(", allocated %.1f MB, heap %.1f MB", float64(result.Alloc)/(1<<20), float64(result.Heap)/(1<<20))
+	}
+	fmt.Println()
+	fmt.Print("  worst:")
+	for _, game := range result.Worst(options.Worst) {
+		fmt.Print(" ", game.Answer, " ", turnsText(game.SimulateResult))
+	}
+	fmt.Println()
+}
+
+// turnsText is the number of guesses or X if the game failed, the way share grids show it
+func turnsText(game gowordle.SimulateResult) string {
+	if !game.Solved {
+		return "X"
+	}
+	return fmt.Sprint(len(game.Guesses))
+}
+
+// printDisagreements prints a table of the answers where the strategies took a different number of guesses
+func printDisagreements(results []gowordle.BenchmarkResult) {
+	disagreements := gowordle.Disagreements(results)
+	fmt.Println(len(disagreements), "answers where the strategies disagree ---------------------")
+	if len(disagreements) == 0 {
+		return
+	}
+	fmt.Printf("%-6s", "answer")
+	for _, result := range results {
+		fmt.Printf(" %10s", result.Strategy)
+	}
+	fmt.Println()
+	for _, i := range disagreements {
+		fmt.Printf("%-6s", results[0].Games[i].Answer)
+		for _, result := range results {
+			fmt.Printf(" %10s", turnsText(result.Games[i].SimulateResult))
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/powellquiring/gowordle/gowordle"
+)
+
+// memoPruneOptions are the memo prune command flags
+type memoPruneOptions struct {
+	Kind    string
+	Stale   bool
+	Smaller int
+}
+
+// memoGroup are the entries of one kind for one guess list
+type memoGroup struct {
+	kind, guesses    string
+	entries          int
+	minSize, maxSize int
+}
+
+// memoFile is the --memo file, the memo commands work on it
+func memoFile() (string, error) {
+	path := gowordle.SolverMemo.Path()
+	if path == "" {
+		return "", fmt.Errorf("provide the memo file with --memo")
+	}
+	return path, nil
+}
+
+// memoStats prints the number of entries of each kind for each guess list
+func memoStats(globalConfig GlobalConfiguration) error {
+	path, err := memoFile()
+	if err != nil {
+		return err
+	}
+	groups := map[[2]string]*memoGroup{}
+	for _, entry := range gowordle.SolverMemo.Entries() {
+		key := [2]string{entry.Kind(), entry.GuessesHash()}
+		group, ok := groups[key]
+		if !ok {
+			group = &memoGroup{kind: key[0], guesses: key[1], minSize: entry.Size}
+			groups[key] = group
+		}
+		group.entries++
+		group.minSize = min(group.minSize, entry.Size)
+		group.maxSize = max(group.maxSize, entry.Size)
+	}
+	sorted := make([]*memoGroup, 0, len(groups))
+	for _, group := range groups {
+		sorted = append(sorted, group)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].kind != sorted[j].kind {
+			return sorted[i].kind < sorted[j].kind
+		}
+		return sorted[i].guesses < sorted[j].guesses
+	})
+	current := gowordle.NewWordleMatcher(globalConfig.Words.Guesses).ContentHash()
+	out := globalConfig.Output
+	if out.Text() {
+		size := int64(0)
+		if info, err := os.Stat(path); err == nil {
+			size = info.Size()
+		}
+		fmt.Println(path, gowordle.SolverMemo.Len(), "entries", size, "bytes")
+		fmt.Println("kind      guesses          entries possible-words")
+	}
+	for _, group := range sorted {
+		isCurrent := group.guesses == current
+		if !out.Text() {
+			if err := out.Write(memoStatsRecord{group.kind, group.guesses, isCurrent, group.entries, group.minSize, group.maxSize}); err != nil {
+				return err
+			}
+			continue
+		}
+		flag := ""
+		if isCurrent {
+			flag = " current guesses"
+		}
+		fmt.Printf("%-9s %.16s %7d %d-%d%s\n", group.kind, group.guesses, group.entries, group.minSize, group.maxSize, flag)
+	}
+	return nil
+}
+
+// memoPrune removes the entries of a kind, the entries for other guess lists or the entries for fewer possible words
+// and rewrites the memo file
+func memoPrune(globalConfig GlobalConfiguration, options memoPruneOptions) error {
+	if _, err := memoFile(); err != nil {
+		return err
+	}
+	if options.Kind == "" && !options.Stale && options.Smaller == 0 {
+		return fmt.Errorf("provide --kind, --stale or --smaller, use memo stats to see the entries")
+	}
+	current := gowordle.NewWordleMatcher(globalConfig.Words.Guesses).ContentHash()
+	removed, err := gowordle.SolverMemo.Prune(func(entry gowordle.MemoEntry) bool {
+		return entry.Kind() != options.Kind &&
+			(!options.Stale || entry.GuessesHash() == current) &&
+			entry.Size >= options.Smaller
+	})
+	if err != nil {
+		return err
+	}
+	if !globalConfig.Output.Text() {
+		return globalConfig.Output.Write(memoPruneRecord{removed, gowordle.SolverMemo.Len()})
+	}
+	fmt.Println("removed", removed, "entries,", gowordle.SolverMemo.Len(), "remaining")
+	return nil
+}
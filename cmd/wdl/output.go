@@ -0,0 +1,318 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+/*
+--output text|json|jsonl|csv
+
+text is the usual output for people.  The other formats write records, json is an array of records, jsonl is one
+record per line and csv is a header line followed by a line per record, lists are joined with spaces.  A command
+that writes a second kind of record, like the sim summary, starts a new csv table: an empty line and its header.
+Feedback colors are always g, y and r, --notation only changes text.  The fields of each record are described in
+the README, keep it up to date.  The records for each command:
+
+	first        firstRecord        one per guess, best first
+	firstbycolor colorRecord        one per answer colors
+	sim          gameRecord         one per game, then a simSummaryRecord
+	sim --boards multiBoardRecord   one per game
+	play         playRecord         one
+	measure      measureRecord      one per strategy and answer
+	arena        measureRecord      one per engine and answer, the strategy is the engine name
+	server       serverRecord       one per guess, also with --absurdle and --absurdle --solve
+	analyze      guessAnalysisRecord one per guess
+	reverse      reverseRecord      one per row of the share grid
+	why          whyRecord          one
+	hint         hintRecord         one, the fields above the level are empty
+	dict audit   auditRecord        one per finding
+	dict diff    diffRecord         one per added or removed answer
+	cache        cacheRecord        one
+	memo stats   memoStatsRecord    one per kind and guess list
+	memo prune   memoPruneRecord    one
+	stats        statsRecord        one, stats export always writes the JSON lines of the stats file
+
+interactive and game are for people and only support text, serve and host responses are always JSON,
+engine speaks the engine protocol.
+*/
+
+const outputText = "text"
+
+var outputFormats = []string{outputText, "json", "jsonl", "csv"}
+
+type firstRecord struct {
+	Guess string `json:"guess"`
+	Score int    `json:"score"` // GuessScore, lower is better
+}
+
+type colorRecord struct {
+	Guess   string   `json:"guess"`
+	Colors  string   `json:"colors"`
+	Count   int      `json:"count"`
+	Answers []string `json:"answers"`
+}
+
+type gameRecord struct {
+	Answer   string   `json:"answer"`
+	Guesses  []string `json:"guesses"`
+	Feedback []string `json:"feedback"` // feedback[i] answer colors for guesses[i]
+	Turns    int      `json:"turns"`
+	Solved   bool     `json:"solved"`
+}
+
+// simSummaryRecord is the last sim record, the pruning fields are 0 unless pruned
+type simSummaryRecord struct {
+	Games           int     `json:"games"` // games played, answers that were skipped are not counted
+	Failed          int     `json:"failed"`
+	MaxGuesses      int     `json:"max_guesses"`
+	Average         float64 `json:"average"` // guesses of the solved games
+	Pruned          bool    `json:"pruned"`  // --puzzle or --date removed the answers used by earlier puzzles
+	Removed         int     `json:"removed"` // answers removed by the pruning
+	UnprunedAverage float64 `json:"unpruned_average"`
+	UnprunedFailed  int     `json:"unpruned_failed"`
+	FewerGuesses    int     `json:"fewer_guesses"` // games solved in fewer guesses because of the pruning
+	MoreGuesses     int     `json:"more_guesses"`
+}
+
+type multiBoardRecord struct {
+	Answers  []string `json:"answers"`
+	Guesses  []string `json:"guesses"`
+	SolvedAt []int    `json:"solved_at"` // solved_at[i] the turn that solved answers[i], 0 if not solved
+	Turns    int      `json:"turns"`
+	Solved   bool     `json:"solved"`
+}
+
+type playRecord struct {
+	Guess            string   `json:"guess"` // suggested next guess
+	Possible         []string `json:"possible"`
+	UnprunedPossible int      `json:"unpruned_possible"` // possible answers without --puzzle or --date pruning, 0 if not pruned
+}
+
+type measureRecord struct {
+	Strategy string   `json:"strategy"`
+	Answer   string   `json:"answer"`
+	Guesses  []string `json:"guesses"`
+	Feedback []string `json:"feedback"`
+	Turns    int      `json:"turns"`
+	Solved   bool     `json:"solved"`
+	Micros   int64    `json:"micros"` // time to play the game
+}
+
+type serverRecord struct {
+	Guess      string   `json:"guess"`
+	Colors     string   `json:"colors"`
+	Remaining  int      `json:"remaining"`
+	Possible   []string `json:"possible"`
+	UpperBound bool     `json:"upper_bound,omitempty"` // --absurdle --solve with a --width, there may be a shorter win
+}
+
+type guessAnalysisRecord struct {
+	Turn         int     `json:"turn"`
+	Guess        string  `json:"guess"`
+	Colors       string  `json:"colors"`
+	Before       int     `json:"before"`
+	After        int     `json:"after"`
+	Best         string  `json:"best"`
+	BestAfter    float64 `json:"best_after"`
+	Expected     float64 `json:"expected"`
+	BestExpected float64 `json:"best_expected"`
+	Skill        float64 `json:"skill"`
+	Luck         float64 `json:"luck"`
+	SkillRating  float64 `json:"skill_rating"`
+	LuckRating   float64 `json:"luck_rating"`
+}
+
+type reverseRecord struct {
+	Row        int      `json:"row"`
+	Colors     string   `json:"colors"`
+	Candidates []string `json:"candidates"`
+	Unique     bool     `json:"unique"`
+}
+
+type whyRecord struct {
+	Word     string   `json:"word"`
+	Possible bool     `json:"possible"` // still a possible answer
+	Reason   string   `json:"reason"`
+	Turn     int      `json:"turn"` // first guess that ruled out the word, 0 if no guess did
+	Guess    string   `json:"guess"`
+	Feedback string   `json:"feedback"`
+	Rule     string   `json:"rule"` // gowordle.Rule... constant
+	Letter   string   `json:"letter"`
+	Position int      `json:"position"` // 1 based spot for the green and same spot rules
+	Count    int      `json:"count"`    // copies required for too few, allowed for too many
+	Turns    []int    `json:"turns"`    // every guess that ruled out the word
+	Rules    []string `json:"rules"`    // rules[i] the rule for turns[i]
+}
+
+type hintRecord struct {
+	Level         int      `json:"level"`
+	Remaining     int      `json:"remaining"`
+	Letters       []string `json:"letters"`        // letters that split the possible answers best, best first
+	LetterAnswers []int    `json:"letter_answers"` // letter_answers[i] possible answers with letters[i]
+	Spot          int      `json:"spot"`
+	SpotLetters   int      `json:"spot_letters"`
+	Repeated      int      `json:"repeated"` // possible answers with a repeated letter
+	Guess         string   `json:"guess"`
+	GuessAfter    float64  `json:"guess_after"` // average possible answers left after the guess
+	Opener        bool     `json:"opener"`      // the guess is the configured first guess
+}
+
+type auditRecord struct {
+	Dictionary string `json:"dictionary"`
+	Line       int    `json:"line"`
+	Word       string `json:"word"`
+	Problem    string `json:"problem"`
+}
+
+type diffRecord struct {
+	Word        string `json:"word"`
+	Change      string `json:"change"` // added or removed
+	Best        string `json:"best"`   // best first guess of the first dictionary with only this change
+	Score       int    `json:"score"`
+	BestChanged bool   `json:"best_changed"`
+}
+
+type memoStatsRecord struct {
+	Kind     string `json:"kind"`
+	Guesses  string `json:"guesses"` // hash of the guess list
+	Current  bool   `json:"current"` // the guess list is the one in use
+	Entries  int    `json:"entries"`
+	MinWords int    `json:"min_words"` // fewest possible words of an entry
+	MaxWords int    `json:"max_words"`
+}
+
+type memoPruneRecord struct {
+	Removed   int `json:"removed"`
+	Remaining int `json:"remaining"`
+}
+
+type statsRecord struct {
+	Played        int     `json:"played"`
+	Won           int     `json:"won"`
+	WinPercent    float64 `json:"win_percent"`
+	CurrentStreak int     `json:"current_streak"`
+	MaxStreak     int     `json:"max_streak"`
+	Distribution  []int   `json:"distribution"` // distribution[i] games won in i+1 guesses
+	Compared      int     `json:"compared"`     // games won with an answer in the answer list
+	Average       float64 `json:"average"`      // guesses in the compared games
+	SolverAverage float64 `json:"solver_average"`
+}
+
+type cacheRecord struct {
+	Guesses int `json:"guesses"`
+	Answers int `json:"answers"`
+	Entries int `json:"entries"`
+}
+
+// output writes records in one of the outputFormats, with text the records are ignored and the commands print
+type output struct {
+	format  string
+	w       io.Writer
+	records []any // json records, written as an array on close
+	csv     *csv.Writer
+	header  []string // of the last csv record
+	closed  bool
+}
+
+func newOutput(format string, w io.Writer) (*output, error) {
+	for _, f := range outputFormats {
+		if f == format {
+			return &output{format: format, w: w}, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown output %s, use one of %s", format, strings.Join(outputFormats, ", "))
+}
+
+// Text is true if the command should print for people
+func (o *output) Text() bool {
+	return o.format == outputText
+}
+
+// Write writes one record, a struct with json tags
+func (o *output) Write(record any) error {
+	switch o.format {
+	case "json":
+		o.records = append(o.records, record)
+	case "jsonl":
+		line, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(o.w, string(line))
+		return err
+	case "csv":
+		header, values := csvFields(record)
+		if o.csv == nil {
+			o.csv = csv.NewWriter(o.w)
+		}
+		if !slices.Equal(header, o.header) {
+			// a different record, like the sim summary after the games, starts a new table
+			if o.header != nil {
+				if err := o.csv.Write([]string{}); err != nil {
+					return err
+				}
+			}
+			o.header = header
+			if err := o.csv.Write(header); err != nil {
+				return err
+			}
+		}
+		return o.csv.Write(values)
+	}
+	return nil
+}
+
+// Close finishes the output, json is written here.  It can be called more than once, commands that exit with an
+// error code close the output first because the exit happens before the root command's After.
+func (o *output) Close() error {
+	if o.closed {
+		return nil
+	}
+	o.closed = true
+	switch o.format {
+	case "json":
+		if o.records == nil {
+			o.records = []any{}
+		}
+		text, err := json.MarshalIndent(o.records, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(o.w, string(text))
+		return err
+	case "csv":
+		if o.csv != nil {
+			o.csv.Flush()
+			return o.csv.Error()
+		}
+	}
+	return nil
+}
+
+// csvFields are the json tag names and the values of the struct fields, slices are joined with spaces
+func csvFields(record any) ([]string, []string) {
+	value := reflect.ValueOf(record)
+	header := []string{}
+	values := []string{}
+	for i := 0; i < value.NumField(); i++ {
+		name, _, _ := strings.Cut(value.Type().Field(i).Tag.Get("json"), ",")
+		header = append(header, name)
+		field := value.Field(i)
+		if field.Kind() == reflect.Slice {
+			items := make([]string, field.Len())
+			for j := range items {
+				items[j] = fmt.Sprint(field.Index(j).Interface())
+			}
+			values = append(values, strings.Join(items, " "))
+		} else {
+			values = append(values, fmt.Sprint(field.Interface()))
+		}
+	}
+	return header, values
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/powellquiring/gowordle/gowordle"
+)
+
+// reverse reads a share grid and prints the guesses that could have produced each row for the answer
+func reverse(globalConfig GlobalConfiguration, answer string, grid []string) error {
+	text := strings.Join(grid, "\n")
+	if len(grid) == 0 {
+		in, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		text = string(in)
+	}
+	shareGrid, err := gowordle.ParseShareGrid(text)
+	if err != nil {
+		return err
+	}
+	if !globalConfig.Output.Text() {
+		for i, row := range gowordle.ReverseSolve(globalConfig.Words.Guesses, gowordle.WordleWord([]rune(answer)), shareGrid.Rows) {
+			if err := globalConfig.Output.Write(reverseRecord{i + 1, string(row.Colors[:]), gowordle.WordleWordsToStrings(row.Candidates), row.Unique}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if shareGrid.Puzzle > 0 {
+		fmt.Println("puzzle", shareGrid.Puzzle, "guesses", len(shareGrid.Rows), "solved", shareGrid.Solved(), "hard mode", shareGrid.HardMode)
+	}
+	rows := gowordle.ReverseSolve(globalConfig.Words.Guesses, gowordle.WordleWord([]rune(answer)), shareGrid.Rows)
+	unique := 0
+	for i, row := range rows {
+		flag := ""
+		if row.Unique {
+			flag = " unique"
+			unique++
+		}
+		fmt.Print(i+1, " ", gowordle.FormatFeedback(row.Colors), " ", len(row.Candidates), flag, ":")
+		for _, word := range row.Candidates {
+			fmt.Print(" ", string(word[:]))
+		}
+		fmt.Println()
+	}
+	fmt.Println(unique, "of", len(rows), "rows uniquely determined")
+	return nil
+}
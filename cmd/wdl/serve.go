@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/powellquiring/gowordle/gowordle"
+)
+
+// serve the HTTP JSON API until the process is stopped
+func serve(globalConfig GlobalConfiguration, addr string) error {
+	api := gowordle.NewAPI(globalConfig.Words, gowordle.WordleWord([]rune(globalConfig.FirstWord)))
+	server := &http.Server{Addr: addr, Handler: api.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	fmt.Println("serving", len(globalConfig.Words.Answers), "answers and", len(globalConfig.Words.Guesses), "guesses on", addr)
+	return server.ListenAndServe()
+}
+
+// hostOptions are the host command flags
+type hostOptions struct {
+	Addr    string
+	Timeout time.Duration
+	State   string
+}
+
+// host games for many players over HTTP until the process is stopped
+func host(globalConfig GlobalConfiguration, options hostOptions) error {
+	gameHost := gowordle.NewGameHost(globalConfig.Words, globalConfig.History, options.Timeout)
+	if options.State != "" {
+		if err := gameHost.Open(options.State); err != nil {
+			return err
+		}
+		defer gameHost.Close()
+		fmt.Println(gameHost.Len(), "games restored from", options.State)
+	}
+	stop := gameHost.ExpireEvery(time.Minute)
+	defer stop()
+	server := &http.Server{Addr: options.Addr, Handler: gameHost.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	fmt.Println("hosting games with", len(globalConfig.Words.Answers), "answers on", options.Addr)
+	return server.ListenAndServe()
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/powellquiring/gowordle/gowordle"
+)
+
+// statsOptions are the stats command flags
+type statsOptions struct {
+	Last int    // games listed
+	Date string // stats add, default is today
+}
+
+// statsFile is the --stats file, <user config dir>/wdl/stats.jsonl if it was not provided
+func statsFile(path string) (string, error) {
+	if path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("provide the stats file with --stats: %w", err)
+	}
+	return filepath.Join(dir, "wdl", "stats.jsonl"), nil
+}
+
+// openStats opens the stats file, creating its directory
+func openStats(path string) (*gowordle.StatsStore, error) {
+	path, err := statsFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	return gowordle.OpenStats(path)
+}
+
+func today() string {
+	return time.Now().Format(time.DateOnly)
+}
+
+// recordGame adds the game to the stats file and tells the person
+func recordGame(globalConfig GlobalConfiguration, game gowordle.StatsGame) error {
+	if puzzle, err := gowordle.ParsePuzzleDate(game.Date); err == nil && game.Puzzle == 0 {
+		game.Puzzle = puzzle
+	}
+	store, err := openStats(globalConfig.Stats)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	added, err := store.Add(game)
+	if err != nil {
+		return err
+	}
+	if !globalConfig.Output.Text() {
+		return nil
+	}
+	answer := game.Answer
+	if answer == "" {
+		answer = "unknown answer"
+	}
+	if !added {
+		fmt.Println("already recorded", game.Date, answer)
+		return nil
+	}
+	fmt.Println("recorded", game.Date, answer, turnsText(gowordle.SimulateResult{Guesses: game.Guesses, Solved: game.Solved}))
+	return nil
+}
+
+// statsAdd records a game entered by hand, it must be solved or use all the guesses
+func statsAdd(globalConfig GlobalConfiguration, options statsOptions, answer string, guesses []string) error {
+	for _, guess := range guesses {
+		if _, err := validGuess(globalConfig, guess); err != nil {
+			return err
+		}
+	}
+	date := options.Date
+	if date == "" {
+		date = today()
+	}
+	game, err := gowordle.NewStatsGame(date, answer, guesses)
+	if err != nil {
+		return err
+	}
+	if !game.Solved && len(game.Guesses) < gowordle.DefaultMaxGuesses {
+		return fmt.Errorf("the game is not over, the last guess must be %s or there must be %d guesses", answer, gowordle.DefaultMaxGuesses)
+	}
+	return recordGame(globalConfig, game)
+}
+
+// recordPlay records the game in the play pairs once it is over, the answer of a lost game is the possible answer
+// if only one is left.  The date is the --puzzle or --date puzzle's date or today.
+func recordPlay(globalConfig GlobalConfiguration, gas []gowordle.GuessAnswer, possible []gowordle.WordleWord) error {
+	game := gowordle.StatsGame{Date: today(), Guesses: []string{}, Colors: []string{}}
+	if globalConfig.Puzzle != noPuzzle {
+		game.Puzzle = globalConfig.Puzzle
+		game.Date = gowordle.FirstPuzzleDate.AddDate(0, 0, globalConfig.Puzzle).Format(time.DateOnly)
+	}
+	for _, ga := range gas {
+		game.Guesses = append(game.Guesses, string(ga.Guess[:]))
+		game.Colors = append(game.Colors, string(ga.Answer[:]))
+	}
+	last := gas[len(gas)-1]
+	if game.Solved = last.Answer == gowordle.WordleWord([]rune("ggggg")); game.Solved {
+		game.Answer = string(last.Guess[:])
+	} else if len(gas) < gowordle.DefaultMaxGuesses {
+		return fmt.Errorf("the game is not over, it is recorded when the last answer is ggggg or after %d guesses", gowordle.DefaultMaxGuesses)
+	} else if len(possible) == 1 {
+		game.Answer = string(possible[0][:])
+	}
+	return recordGame(globalConfig, game)
+}
+
+// statsShow prints the statistics and compares the last games with the games the solver plays for the same answers
+func statsShow(globalConfig GlobalConfiguration, options statsOptions) error {
+	store, err := openStats(globalConfig.Stats)
+	if err != nil {
+		return err
+	}
+	store.Close()
+	summary := gowordle.Summarize(store.Games, today())
+
+	// the solver plays the answers of the games that were won and are in the answer list
+	compared := []gowordle.StatsGame{}
+	answers := []string{}
+	for _, game := range store.Games {
+		if game.Solved && slices.Contains(globalConfig.Answers, game.Answer) {
+			compared = append(compared, game)
+			answers = append(answers, game.Answer)
+		}
+	}
+	strategy, err := gowordle.FindStrategy(defaultStrategy(globalConfig))
+	if err != nil {
+		return err
+	}
+	solver := gowordle.RunBenchmark(globalConfig.Words, strategy, answers, globalConfig.FirstWord, gowordle.DefaultMaxGuesses, runtime.NumCPU(), nil)
+	solverGuesses := map[string]int{}
+	yours, theirs := 0, 0
+	for i, game := range compared {
+		played := solver.Games[i].SimulateResult
+		solverGuesses[game.Answer] = len(played.Guesses)
+		yours += len(game.Guesses)
+		theirs += len(played.Guesses)
+	}
+	average, solverAverage := 0.0, 0.0
+	if len(compared) > 0 {
+		average, solverAverage = float64(yours)/float64(len(compared)), float64(theirs)/float64(len(compared))
+	}
+	if !globalConfig.Output.Text() {
+		return globalConfig.Output.Write(statsRecord{summary.Played, summary.Won, summary.WinPercent, summary.CurrentStreak,
+			summary.MaxStreak, summary.Distribution, len(compared), average, solverAverage})
+	}
+
+	fmt.Printf("played %d, won %.0f%%, current streak %d, max streak %d\n", summary.Played, summary.WinPercent, summary.CurrentStreak, summary.MaxStreak)
+	most := slices.Max(summary.Distribution)
+	for i, count := range summary.Distribution {
+		bar := 0
+		if most > 0 {
+			bar = 30 * count / most
+		}
+		fmt.Printf("%d %s %d\n", i+1, strings.Repeat("#", bar), count)
+	}
+	if len(compared) > 0 {
+		fmt.Printf("you averaged %.2f guesses, %s from %s averaged %.2f for the same %d answers\n", average, strategy.Name,
+			globalConfig.FirstWord, solverAverage, len(compared))
+	}
+	games := store.Games[max(0, len(store.Games)-options.Last):]
+	if len(games) > 0 {
+		fmt.Println("last", len(games), "games ---------------------")
+	}
+	for _, game := range games {
+		answer := game.Answer
+		if answer == "" {
+			answer = "?????"
+		}
+		fmt.Print(game.Date, " ", answer, " ", turnsText(gowordle.SimulateResult{Guesses: game.Guesses, Solved: game.Solved}),
+			"/", gowordle.DefaultMaxGuesses, " ", strings.Join(game.Guesses, " "))
+		if turns, ok := solverGuesses[game.Answer]; ok && game.Solved {
+			fmt.Printf(" (solver %d, %+d)", turns, len(game.Guesses)-turns)
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+// statsExport writes the stats file to the file or stdout if it is empty or -
+func statsExport(globalConfig GlobalConfiguration, file string) error {
+	store, err := openStats(globalConfig.Stats)
+	if err != nil {
+		return err
+	}
+	store.Close()
+	if file == "" || file == "-" {
+		return gowordle.WriteStatsGames(os.Stdout, store.Games)
+	}
+	out, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	if err := gowordle.WriteStatsGames(out, store.Games); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// statsImport adds the games in the exported files that are not already recorded
+func statsImport(globalConfig GlobalConfiguration, files []string) error {
+	store, err := openStats(globalConfig.Stats)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	for _, file := range files {
+		in, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		games, err := gowordle.ReadStatsGames(in)
+		in.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		added, err := store.Import(games)
+		if err != nil {
+			return err
+		}
+		if globalConfig.Output.Text() {
+			fmt.Println(file, "imported", added, "games,", len(games)-added, "already recorded")
+		}
+	}
+	return nil
+}
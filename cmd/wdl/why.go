@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/powellquiring/gowordle/gowordle"
+)
+
+// parseHistory parses the guess/answer pairs
+func parseHistory(pairs []string) ([]gowordle.GuessAnswer, error) {
+	ret := []gowordle.GuessAnswer{}
+	for i := 0; i < len(pairs); i += 2 {
+		guess, err := gowordle.ParseGuess(strings.ToLower(pairs[i]))
+		if err != nil {
+			return nil, err
+		}
+		answer, err := gowordle.ParseFeedback(pairs[i+1])
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, gowordle.GuessAnswer{Guess: guess, Answer: answer})
+	}
+	return ret, nil
+}
+
+// replaySession is a session after the guess/answer pairs, it is an error if no answers are left
+func replaySession(globalConfig GlobalConfiguration, pairs []string) (*gowordle.Session, error) {
+	history, err := parseHistory(pairs)
+	if err != nil {
+		return nil, err
+	}
+	session := gowordle.NewSession(globalConfig.Words, gowordle.WordleWord([]rune(globalConfig.FirstWord)))
+	for _, ga := range history {
+		if err := session.Apply(ga.Guess, ga.Answer); err != nil {
+			return nil, err
+		}
+	}
+	return session, nil
+}
+
+// why explains if the word is still possible after the guess/answer pairs or the rule that ruled it out
+func why(globalConfig GlobalConfiguration, word string, pairs []string) error {
+	wordleWord, err := gowordle.ParseGuess(strings.ToLower(word))
+	if err != nil {
+		return err
+	}
+	history, err := parseHistory(pairs)
+	if err != nil {
+		return err
+	}
+	// the history is replayed even if it leaves no answers, the guesses that contradict each other are explained
+	session := gowordle.NewSession(globalConfig.Words, gowordle.WordleWord([]rune(globalConfig.FirstWord)))
+	for _, ga := range history {
+		session.Replay(ga.Guess, ga.Answer)
+	}
+	reason := session.Why(wordleWord)
+	if !globalConfig.Output.Text() {
+		record := whyRecord{Word: string(wordleWord[:]), Reason: reason, Turns: []int{}, Rules: []string{}}
+		if exclusions := gowordle.ExclusionsByHistory(wordleWord, session.History); len(exclusions) > 0 {
+			exclusion := exclusions[0]
+			record.Turn = exclusion.Turn
+			record.Guess = string(exclusion.Guess[:])
+			record.Feedback = string(exclusion.Feedback[:])
+			record.Rule = exclusion.Rule
+			record.Letter = string(exclusion.Letter)
+			record.Position = exclusion.Position
+			record.Count = exclusion.Count
+			for _, exclusion := range exclusions {
+				record.Turns = append(record.Turns, exclusion.Turn)
+				record.Rules = append(record.Rules, exclusion.Rule)
+			}
+		} else {
+			for _, possible := range session.Possible() {
+				record.Possible = record.Possible || possible == wordleWord
+			}
+		}
+		return globalConfig.Output.Write(record)
+	}
+	fmt.Println(reason)
+	if len(session.Possible()) == 0 {
+		fmt.Println("no answer matches every guess, the guesses contradict each other")
+	}
+	return nil
+}
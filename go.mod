@@ -5,7 +5,10 @@ go 1.24.0
 require (
 	github.com/bits-and-blooms/bitset v1.2.2
 	github.com/deckarep/golang-set v1.8.0
+	github.com/schollz/progressbar/v3 v3.18.0
 	github.com/stretchr/testify v1.10.0
+	github.com/urfave/cli/v3 v3.4.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -13,9 +16,6 @@ require (
 	github.com/mitchellh/colorstring v0.0.0-20190213212951-d06e56a500db // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/rivo/uniseg v0.4.7 // indirect
-	github.com/schollz/progressbar/v3 v3.18.0 // indirect
-	github.com/urfave/cli/v3 v3.4.1 // indirect
 	golang.org/x/sys v0.36.0 // indirect
 	golang.org/x/term v0.35.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
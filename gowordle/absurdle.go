@@ -0,0 +1,149 @@
+package gowordle
+
+import (
+	"sort"
+)
+
+/*
+Absurdle - the host never commits to a solution.  After each guess the host partitions the
+remaining candidates by answer colors (see AnswerPartition) and keeps the largest bucket alive.
+*/
+
+var allGreen = WordleWord([]rune("ggggg"))
+
+// countColor returns the number of letters in the answer with the color
+func countColor(answer WordleWord, color rune) int {
+	ret := 0
+	for _, c := range answer {
+		if c == color {
+			ret++
+		}
+	}
+	return ret
+}
+
+// absurdleBetter is true if bucket a is kept by the host instead of bucket b.  The bigger bucket wins,
+// ties go to the answer that gives away the least: fewer greens, then fewer yellows, then the
+// alphabetically smaller answer colors so the host is deterministic.
+func absurdleBetter(aColors WordleWord, aLen int, bColors WordleWord, bLen int) bool {
+	if aLen != bLen {
+		return aLen > bLen
+	}
+	if aGreen, bGreen := countColor(aColors, 'g'), countColor(bColors, 'g'); aGreen != bGreen {
+		return aGreen < bGreen
+	}
+	if aYellow, bYellow := countColor(aColors, 'y'), countColor(bColors, 'y'); aYellow != bYellow {
+		return aYellow < bYellow
+	}
+	return string(aColors[:]) < string(bColors[:])
+}
+
+// AbsurdleAnswer returns the answer colors the adversarial host gives for the guess and the candidates that remain.
+// ggggg is only returned when the guess is the last remaining candidate.
+func AbsurdleAnswer(possibleWords []WordleWord, guess WordleWord) (WordleWord, []WordleWord) {
+	if len(possibleWords) == 0 {
+		panic("possibleWords is empty")
+	}
+	var bestColors WordleWord
+	var bestWords []WordleWord
+	for colors, words := range AnswerPartition(possibleWords, guess) {
+		if bestWords == nil || absurdleBetter(colors, len(words), bestColors, len(bestWords)) {
+			bestColors = colors
+			bestWords = words
+		}
+	}
+	return bestColors, bestWords
+}
+
+// AbsurdleGame is an adversarial game in progress, the host keeps the largest set of candidates alive
+type AbsurdleGame struct {
+	Possible []WordleWord
+	Guesses  []GuessAnswer
+}
+
+func NewAbsurdleGame(possibleWords []WordleWord) *AbsurdleGame {
+	return &AbsurdleGame{Possible: possibleWords}
+}
+
+// Guess plays the guess against the host and returns the answer colors
+func (ag *AbsurdleGame) Guess(guess WordleWord) WordleWord {
+	colors, possible := AbsurdleAnswer(ag.Possible, guess)
+	ag.Possible = possible
+	ag.Guesses = append(ag.Guesses, GuessAnswer{guess, colors})
+	return colors
+}
+
+// Solved is true once the host has been forced to answer ggggg
+func (ag *AbsurdleGame) Solved() bool {
+	return len(ag.Guesses) > 0 && ag.Guesses[len(ag.Guesses)-1].Answer == allGreen
+}
+
+type absurdleMove struct {
+	guess    WordleWord
+	possible []WordleWord
+}
+
+// absurdleMoves returns the guesses that make progress against the host, the ones that leave the fewest
+// candidates first.  width limits the number of moves returned, 0 is all of them.
+func absurdleMoves(allWords, possibleWords []WordleWord, width int) []absurdleMove {
+	ret := make([]absurdleMove, 0)
+	for _, guess := range allWords {
+		_, possible := AbsurdleAnswer(possibleWords, guess)
+		if len(possible) == len(possibleWords) {
+			continue // host did not have to give anything away
+		}
+		ret = append(ret, absurdleMove{guess, possible})
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return len(ret[i].possible) < len(ret[j].possible)
+	})
+	if width > 0 && len(ret) > width {
+		ret = ret[:width]
+	}
+	return ret
+}
+
+// absurdleFailed remembers the candidate sets (WordleMatcher ContentHash) that can not be won in the number of
+// guesses.  The same candidates are reached by different guesses in a different order so the key is the content.
+type absurdleFailed map[string]int
+
+// absurdleSearch returns the guesses that win against the host in guessesLeft or fewer guesses
+func absurdleSearch(allWords, possibleWords []WordleWord, guessesLeft int, width int, failed absurdleFailed) ([]WordleWord, bool) {
+	if guessesLeft < 1 {
+		return nil, false
+	}
+	if len(possibleWords) == 1 {
+		return []WordleWord{possibleWords[0]}, true
+	}
+	if guessesLeft == 1 {
+		return nil, false // the host will never say ggggg with two or more candidates left
+	}
+	key := contentHash(possibleWords)
+	if left, ok := failed[key]; ok && left >= guessesLeft {
+		return nil, false
+	}
+	for _, move := range absurdleMoves(allWords, possibleWords, width) {
+		if guessesLeft == 2 && len(move.possible) > 1 {
+			break // moves are sorted so no other move narrows it down to one
+		}
+		if guesses, ok := absurdleSearch(allWords, move.possible, guessesLeft-1, width, failed); ok {
+			return append([]WordleWord{move.guess}, guesses...), true
+		}
+	}
+	failed[key] = guessesLeft
+	return nil, false
+}
+
+// AbsurdleSolve finds the fewest guesses that are guaranteed to beat the adversarial host, trying 1 up to maxGuesses.
+// At each turn only the width guesses that leave the fewest candidates are tried so the number of guesses is an upper
+// bound, a narrower search may miss a shorter win.  0 tries every word in allWords which finds the true minimum but
+// is slow for large dictionaries.  The last guess returned is the solution.
+func AbsurdleSolve(allWords, possibleWords []WordleWord, maxGuesses int, width int) ([]WordleWord, bool) {
+	failed := make(absurdleFailed)
+	for guesses := 1; guesses <= maxGuesses; guesses++ {
+		if ret, ok := absurdleSearch(allWords, possibleWords, guesses, width, failed); ok {
+			return ret, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,180 @@
+package gowordle
+
+import "sync"
+
+/*
+Post game analysis.  Each guess of a game is compared to the guess the strategy (BestGuess1) would have made.
+Expected guesses are the average number of guesses to solve over all the possible words when the strategy
+plays the rest of the game.
+*/
+
+// strategyGuessCache remembers the strategy's next guess for a set of possible words, key analyzeKey
+var strategyGuessCache map[string]WordleWord = make(map[string]WordleWord)
+
+// expectedGuessesCache remembers the expected guesses for the strategy to solve a set of possible words, key analyzeKey
+var expectedGuessesCache map[string]float64 = make(map[string]float64)
+
+// analyzeLock guards both caches
+var analyzeLock sync.Mutex
+
+// analyzeKey is the key of the caches, the hashes of the guesses and of the possible words
+func analyzeKey(allWords GuessList, possibleWords []WordleWord) string {
+	return allWords.Hash + ":" + contentHash(possibleWords)
+}
+
+// StrategyGuess is the guess the strategy makes for the possible words
+func StrategyGuess(allWords GuessList, possibleWords []WordleWord) WordleWord {
+	key := analyzeKey(allWords, possibleWords)
+	analyzeLock.Lock()
+	ret, ok := strategyGuessCache[key]
+	analyzeLock.Unlock()
+	if ok {
+		return ret
+	}
+	ret = nextGuess(BestGuess1, allWords, possibleWords)
+	analyzeLock.Lock()
+	strategyGuessCache[key] = ret
+	analyzeLock.Unlock()
+	return ret
+}
+
+// ExpectedGuesses is the average number of guesses it takes the strategy to solve each of the possible words
+func ExpectedGuesses(allWords GuessList, possibleWords []WordleWord) float64 {
+	if len(possibleWords) == 1 {
+		return 1
+	}
+	key := analyzeKey(allWords, possibleWords)
+	analyzeLock.Lock()
+	ret, ok := expectedGuessesCache[key]
+	analyzeLock.Unlock()
+	if ok {
+		return ret
+	}
+	ret = ExpectedGuessesForGuess(allWords, possibleWords, StrategyGuess(allWords, possibleWords))
+	analyzeLock.Lock()
+	expectedGuessesCache[key] = ret
+	analyzeLock.Unlock()
+	return ret
+}
+
+// ExpectedGuessesForGuess is the average number of guesses to solve each of the possible words
+// when the guess is made first and the strategy plays the rest of the game
+func ExpectedGuessesForGuess(allWords GuessList, possibleWords []WordleWord, guess WordleWord) float64 {
+	total := 0.0
+	for colors, solutions := range AnswerPartition(possibleWords, guess) {
+		if colors == allGreen {
+			total += 1
+			continue
+		}
+		if len(solutions) == len(possibleWords) {
+			// a wasted guess, it did not narrow down the possible words.  Guessing a possible word always narrows it down
+			next := StrategyGuess(allWords, possibleWords)
+			if next == guess {
+				next = possibleWords[0]
+			}
+			total += float64(len(solutions)) * (1 + ExpectedGuessesForGuess(allWords, possibleWords, next))
+			continue
+		}
+		total += float64(len(solutions)) * (1 + ExpectedGuesses(allWords, solutions))
+	}
+	return total / float64(len(possibleWords))
+}
+
+type GuessAnalysis struct {
+	Guess        WordleWord
+	Colors       WordleWord
+	Before       int        // possible words before the guess
+	After        int        // possible words after the guess
+	Best         WordleWord // the strategy's guess
+	BestAfter    float64    // average possible words remaining after the strategy's guess
+	Expected     float64    // expected guesses to finish the game with Guess
+	BestExpected float64    // expected guesses to finish the game with Best
+	Skill        float64    // guesses gained by the choice of guess, BestExpected - Expected, negative when Best is better
+	Luck         float64    // guesses gained by the colors received, Expected - (1 + expected guesses for the words remaining)
+	SkillRating  float64    // 0-100, 100 * BestExpected / Expected
+	LuckRating   float64    // 0-100, percent of the possible words that would have left more possible words than received
+}
+
+type GameAnalysis struct {
+	Solution     WordleWord
+	Guesses      []GuessAnalysis
+	Solved       bool
+	Skill        float64 // total guesses gained (negative lost) by choice of guesses compared to the strategy
+	Luck         float64 // total guesses gained (negative lost) by the colors received
+	SkillRating  float64 // average of the guess ratings
+	LuckRating   float64 // average of the guess ratings
+	BestExpected float64 // expected guesses for the strategy to solve a game from the start
+}
+
+// AnalyzeGame replays the guesses against the solution.  Each guess is compared to the strategy's guess,
+// for the first guess the strategy's guess is firstGuess to avoid scoring every word against every word.
+func AnalyzeGame(allWords GuessList, possibleWords []WordleWord, solution WordleWord, guesses []WordleWord, firstGuess WordleWord) GameAnalysis {
+	ret := GameAnalysis{Solution: solution}
+	possible := possibleWords
+	for turn, guess := range guesses {
+		colors := WordleAnswer(solution, guess)
+		partition := AnswerPartition(possible, guess)
+		ga := GuessAnalysis{
+			Guess:  guess,
+			Colors: colors,
+			Before: len(possible),
+			After:  len(partition[colors]),
+		}
+		if turn == 0 {
+			ga.Best = firstGuess
+		} else {
+			ga.Best = StrategyGuess(allWords, possible)
+		}
+		ga.Expected = ExpectedGuessesForGuess(allWords, possible, guess)
+		ga.BestExpected = ExpectedGuessesForGuess(allWords, possible, ga.Best)
+		ga.BestAfter = averageRemaining(AnswerPartition(possible, ga.Best), len(possible))
+		if turn == 0 {
+			ret.BestExpected = ga.BestExpected
+		}
+		ga.Skill = ga.BestExpected - ga.Expected
+		ga.SkillRating = 100
+		if ga.Expected > ga.BestExpected {
+			ga.SkillRating = 100 * ga.BestExpected / ga.Expected
+		}
+		after := 1.0
+		if colors != allGreen {
+			after = 1 + ExpectedGuesses(allWords, partition[colors])
+		}
+		ga.Luck = ga.Expected - after
+		worse := 0
+		for answerColors, solutions := range partition {
+			if answerColors != colors && len(solutions) > ga.After {
+				worse += len(solutions)
+			}
+		}
+		ga.LuckRating = 100 * float64(worse) / float64(len(possible))
+
+		ret.Guesses = append(ret.Guesses, ga)
+		ret.Skill += ga.Skill
+		ret.Luck += ga.Luck
+		ret.SkillRating += ga.SkillRating
+		ret.LuckRating += ga.LuckRating
+		if colors == allGreen {
+			ret.Solved = true
+			break
+		}
+		possible = partition[colors]
+		if len(possible) == 0 {
+			panic("solution is not one of the possible words")
+		}
+	}
+	if len(ret.Guesses) > 0 {
+		ret.SkillRating /= float64(len(ret.Guesses))
+		ret.LuckRating /= float64(len(ret.Guesses))
+	}
+	return ret
+}
+
+// averageRemaining is the average number of possible words left after the guess that made the partition
+func averageRemaining(partition map[WordleWord][]WordleWord, possible int) float64 {
+	total := 0
+	for _, solutions := range partition {
+		total += len(solutions) * len(solutions)
+	}
+	return float64(total) / float64(possible)
+}
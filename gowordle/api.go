@@ -0,0 +1,343 @@
+package gowordle
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+)
+
+/*
+The API serves the solver over HTTP for a web frontend.  Each endpoint is a POST of a JSON request that returns a
+JSON response, the word lists, matchers and solver caches stay in memory between requests so they get faster.
+Feedback in requests can be any notation ParseFeedback accepts, responses always use g, y and r.
+
+	/filter    {"history": [{"guess": "crane", "feedback": "rrrrg"}]}
+	           {"count": 102, "candidates": ["abide", ...]}
+	/suggest   {"history": [...], "strategy": "matches", "k": 5}
+	           {"strategy": "matches", "count": 102, "suggestions": [{"guess": "guilt", "score": 416, "best": true}, ...]}
+	/partition {"history": [...], "guess": "guilt"}
+	           {"guess": "guilt", "count": 102, "average": 4.08, "partition": [{"feedback": "rrrrr", "count": 14, "answers": [...]}, ...]}
+	/feedback  {"solution": "crane", "guess": "react"}
+	           {"feedback": "yyyyr"}
+	/simulate  {"answer": "crane", "strategy": "matches", "first": "raise", "max_guesses": 6}
+	           {"answer": "crane", "guesses": ["raise", ...], "colors": ["yyrrg", ...], "solved": true}
+
+The suggestions are the guesses with the lowest score of the strategy's Rank, for matches and answers the total
+number of answers left for each possible answer, the strategy's best guess is always first.  A strategy that does
+not score each guess, like recursive, can not suggest.  Before any guesses the strategy's guess is the first guess.
+Ranking every guess for all the answers is slow, the first /suggest with no history takes a while unless the
+SolverMemo has it.
+A request that is not valid gets a 400 response with {"error": "..."}.
+*/
+
+// MaxSuggestions is the largest k for /suggest
+const MaxSuggestions = 100
+
+// maxRequestBytes is the largest request body accepted
+const maxRequestBytes = 1 << 20
+
+type API struct {
+	Words      *WordLists
+	FirstGuess WordleWord // the strategy's guess before any guesses, scoring every guess against every answer is slow
+	MaxGuesses int        // default for /simulate
+}
+
+type APIGuessFeedback struct {
+	Guess    string `json:"guess"`
+	Feedback string `json:"feedback"`
+}
+
+type APIFilterRequest struct {
+	History []APIGuessFeedback `json:"history"`
+}
+
+type APIFilterResponse struct {
+	Count      int      `json:"count"`
+	Candidates []string `json:"candidates"`
+}
+
+type APISuggestRequest struct {
+	History  []APIGuessFeedback `json:"history"`
+	Strategy string             `json:"strategy"` // default is the first of Strategies, it must have a Rank
+	K        int                `json:"k"`        // default 10
+}
+
+type APISuggestion struct {
+	Guess string `json:"guess"`
+	Score int    `json:"score"` // the strategy's Rank score, lower is better
+	Best  bool   `json:"best"`  // the strategy's guess
+}
+
+type APISuggestResponse struct {
+	Strategy    string          `json:"strategy"`
+	Count       int             `json:"count"` // possible answers
+	Suggestions []APISuggestion `json:"suggestions"`
+}
+
+type APIPartitionRequest struct {
+	History []APIGuessFeedback `json:"history"`
+	Guess   string             `json:"guess"`
+}
+
+type APIPartitionGroup struct {
+	Feedback string   `json:"feedback"`
+	Count    int      `json:"count"`
+	Answers  []string `json:"answers"`
+}
+
+type APIPartitionResponse struct {
+	Guess     string              `json:"guess"`
+	Count     int                 `json:"count"`     // possible answers before the guess
+	Average   float64             `json:"average"`   // average possible answers left after the guess
+	Partition []APIPartitionGroup `json:"partition"` // largest group first
+}
+
+type APIFeedbackRequest struct {
+	Solution string `json:"solution"`
+	Guess    string `json:"guess"`
+}
+
+type APIFeedbackResponse struct {
+	Feedback string `json:"feedback"`
+}
+
+type APISimulateRequest struct {
+	Answer     string `json:"answer"`
+	Strategy   string `json:"strategy"`    // default is the first of Strategies
+	First      string `json:"first"`       // default is FirstGuess
+	MaxGuesses int    `json:"max_guesses"` // default is MaxGuesses
+}
+
+type APIError struct {
+	Error string `json:"error"`
+}
+
+func NewAPI(words *WordLists, firstGuess WordleWord) *API {
+	return &API{Words: words, FirstGuess: firstGuess, MaxGuesses: DefaultMaxGuesses}
+}
+
+// Handler serves the endpoints
+func (a *API) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /filter", apiHandler(a.filter))
+	mux.HandleFunc("POST /suggest", apiHandler(a.suggest))
+	mux.HandleFunc("POST /partition", apiHandler(a.partition))
+	mux.HandleFunc("POST /feedback", apiHandler(a.feedback))
+	mux.HandleFunc("POST /simulate", apiHandler(a.simulate))
+	return mux
+}
+
+// apiHandler decodes the request, rejecting unknown fields, and encodes the response or the error
+func apiHandler[Request any, Response any](endpoint func(Request) (Response, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var request Request
+		if err := decodeRequest(w, r, &request); err != nil {
+			writeJSON(w, http.StatusBadRequest, APIError{"bad request: " + err.Error()})
+			return
+		}
+		response, err := endpoint(request)
+		writeResponse(w, response, err)
+	}
+}
+
+// decodeRequest decodes one JSON value from the body, unknown fields are an error
+func decodeRequest(w http.ResponseWriter, r *http.Request, request any) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(request); err != nil {
+		return err
+	}
+	if decoder.More() {
+		return errors.New("more than one JSON value")
+	}
+	return nil
+}
+
+// apiStatusError is an error with a status other than 400
+type apiStatusError struct {
+	status int
+	err    error
+}
+
+func (e apiStatusError) Error() string {
+	return e.err.Error()
+}
+
+// writeResponse writes the response or the error, a 400 unless it is an apiStatusError
+func writeResponse(w http.ResponseWriter, response any, err error) {
+	if err != nil {
+		status := http.StatusBadRequest
+		statusError := apiStatusError{}
+		if errors.As(err, &statusError) {
+			status = statusError.status
+		}
+		writeJSON(w, status, APIError{err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+// guess is a valid guess
+func (a *API) guess(guess string) (WordleWord, error) {
+	ret, err := ParseGuess(guess)
+	if err != nil {
+		return ret, err
+	}
+	if !a.Words.ValidGuess(ret) {
+		return ret, fmt.Errorf("%s is not in the guess list", guess)
+	}
+	return ret, nil
+}
+
+// possible are the answers left after the history, it is an error if none are left
+func (a *API) possible(history []APIGuessFeedback) ([]GuessAnswer, []WordleWord, error) {
+	gas := make([]GuessAnswer, 0, len(history))
+	possible := a.Words.Answers
+	for i, gf := range history {
+		guess, err := a.guess(gf.Guess)
+		if err != nil {
+			return nil, nil, fmt.Errorf("history %d: %w", i+1, err)
+		}
+		feedback, err := ParseFeedback(gf.Feedback)
+		if err != nil {
+			return nil, nil, fmt.Errorf("history %d: %w", i+1, err)
+		}
+		gas = append(gas, GuessAnswer{guess, feedback})
+		possible = NewWordleMatcher(possible).Matching(guess, feedback)
+	}
+	if len(possible) == 0 {
+		return nil, nil, fmt.Errorf("no possible answers for the history")
+	}
+	return gas, possible, nil
+}
+
+// strategy is the strategy with the name, the default strategy if name is empty
+func (a *API) strategy(name string) (Strategy, error) {
+	if name == "" {
+		return Strategies[0], nil
+	}
+	return FindStrategy(name)
+}
+
+func (a *API) filter(request APIFilterRequest) (APIFilterResponse, error) {
+	_, possible, err := a.possible(request.History)
+	if err != nil {
+		return APIFilterResponse{}, err
+	}
+	return APIFilterResponse{len(possible), WordleWordsToStrings(possible)}, nil
+}
+
+func (a *API) suggest(request APISuggestRequest) (APISuggestResponse, error) {
+	if request.K == 0 {
+		request.K = 10
+	}
+	if request.K < 1 || request.K > MaxSuggestions {
+		return APISuggestResponse{}, fmt.Errorf("k %d is not 1 to %d", request.K, MaxSuggestions)
+	}
+	strategy, err := a.strategy(request.Strategy)
+	if err != nil {
+		return APISuggestResponse{}, err
+	}
+	if strategy.Rank == nil {
+		return APISuggestResponse{}, fmt.Errorf("strategy %s does not score each guess, it can not suggest", strategy.Name)
+	}
+	gas, possible, err := a.possible(request.History)
+	if err != nil {
+		return APISuggestResponse{}, err
+	}
+	best := a.FirstGuess
+	if len(gas) > 0 {
+		best = nextGuess(strategy.BestGuess, a.Words.GuessList(), possible)
+	}
+	ranked := strategy.Rank(a.Words.GuessList(), possible)
+	ret := APISuggestResponse{Strategy: strategy.Name, Count: len(possible), Suggestions: []APISuggestion{}}
+	for _, item := range ranked {
+		if item.Value == best {
+			ret.Suggestions = append(ret.Suggestions, APISuggestion{string(best[:]), item.Score, true})
+			break
+		}
+	}
+	for _, item := range ranked {
+		if len(ret.Suggestions) >= request.K {
+			break
+		}
+		if item.Value != best {
+			ret.Suggestions = append(ret.Suggestions, APISuggestion{string(item.Value[:]), item.Score, false})
+		}
+	}
+	return ret, nil
+}
+
+func (a *API) partition(request APIPartitionRequest) (APIPartitionResponse, error) {
+	guess, err := a.guess(request.Guess)
+	if err != nil {
+		return APIPartitionResponse{}, err
+	}
+	_, possible, err := a.possible(request.History)
+	if err != nil {
+		return APIPartitionResponse{}, err
+	}
+	partition := AnswerPartition(possible, guess)
+	ret := APIPartitionResponse{Guess: request.Guess, Count: len(possible), Average: averageRemaining(partition, len(possible))}
+	for colors, answers := range partition {
+		ret.Partition = append(ret.Partition, APIPartitionGroup{string(colors[:]), len(answers), WordleWordsToStrings(answers)})
+	}
+	sort.Slice(ret.Partition, func(i, j int) bool {
+		if ret.Partition[i].Count != ret.Partition[j].Count {
+			return ret.Partition[i].Count > ret.Partition[j].Count
+		}
+		return ret.Partition[i].Feedback < ret.Partition[j].Feedback
+	})
+	return ret, nil
+}
+
+func (a *API) feedback(request APIFeedbackRequest) (APIFeedbackResponse, error) {
+	solution, err := ParseGuess(request.Solution)
+	if err != nil {
+		return APIFeedbackResponse{}, fmt.Errorf("solution: %w", err)
+	}
+	guess, err := ParseGuess(request.Guess)
+	if err != nil {
+		return APIFeedbackResponse{}, err
+	}
+	colors := WordleAnswer(solution, guess)
+	return APIFeedbackResponse{string(colors[:])}, nil
+}
+
+func (a *API) simulate(request APISimulateRequest) (SimulateResult, error) {
+	answer, err := ParseGuess(request.Answer)
+	if err != nil {
+		return SimulateResult{}, fmt.Errorf("answer: %w", err)
+	}
+	if !slices.Contains(a.Words.Answers, answer) {
+		return SimulateResult{}, fmt.Errorf("answer %s is not in the answer list", request.Answer)
+	}
+	strategy, err := a.strategy(request.Strategy)
+	if err != nil {
+		return SimulateResult{}, err
+	}
+	first := string(a.FirstGuess[:])
+	if request.First != "" {
+		if _, err := a.guess(request.First); err != nil {
+			return SimulateResult{}, fmt.Errorf("first: %w", err)
+		}
+		first = request.First
+	}
+	maxGuesses := a.MaxGuesses
+	if request.MaxGuesses != 0 {
+		maxGuesses = request.MaxGuesses
+	}
+	if maxGuesses < 1 || maxGuesses > 100 {
+		return SimulateResult{}, fmt.Errorf("max_guesses %d is not 1 to 100", maxGuesses)
+	}
+	return SimulateStrategy(a.Words, strategy.BestGuess, request.Answer, first, maxGuesses), nil
+}
@@ -0,0 +1,209 @@
+package gowordle
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+)
+
+/*
+Benchmark a strategy by simulating a game for each answer.  Games are played by a pool of workers, the solver
+caches are shared so a strategy gets faster as it goes.
+*/
+
+type Strategy struct {
+	Name      string
+	Usage     string
+	BestGuess BestGuessFunc
+	Rank      RankFunc // nil if the strategy does not score each guess
+}
+
+// RankFunc returns the guesses the strategy considers with their scores, lowest score first
+type RankFunc func(guesses GuessList, possibleWords []WordleWord) []Item
+
+// Strategies that can be benchmarked, the first is the default
+var Strategies = []Strategy{
+	{"matches", "guess with the fewest total matching answers", ScoreAlgorithmTotalMatches1Level, RankGuesses},
+	{"recursive", "search the game tree for the fewest guesses, slower but better", ScoreAlgorithmRecursive, nil},
+	{"answers", "fewest total matching answers guessing only possible answers", ScoreAlgorithmAnswersOnly, RankAnswersOnly},
+}
+
+// ScoreAlgorithmAnswersOnly is ScoreAlgorithmTotalMatches1Level guessing only the possible words
+func ScoreAlgorithmAnswersOnly(guesses GuessList, possibleWords, initialGuesses []WordleWord, depth int, bestScoreSoFar int) (int, []WordleWord) {
+	return ScoreAlgorithmTotalMatches1Level(NewGuessList(possibleWords), possibleWords, initialGuesses, depth, bestScoreSoFar)
+}
+
+// RankAnswersOnly is RankGuesses guessing only the possible words, the ranking of ScoreAlgorithmAnswersOnly
+func RankAnswersOnly(guesses GuessList, possibleWords []WordleWord) []Item {
+	return RankGuesses(NewGuessList(possibleWords), possibleWords)
+}
+
+// FindStrategy returns the strategy with the name
+func FindStrategy(name string) (Strategy, error) {
+	names := []string{}
+	for _, strategy := range Strategies {
+		if strategy.Name == name {
+			return strategy, nil
+		}
+		names = append(names, strategy.Name)
+	}
+	return Strategy{}, fmt.Errorf("unknown strategy %s, use one of %v", name, names)
+}
+
+type BenchmarkGame struct {
+	SimulateResult
+	Duration time.Duration
+}
+
+type BenchmarkResult struct {
+	Strategy string
+	Games    []BenchmarkGame // in the order of the answers
+	Duration time.Duration   // wall clock time for all the games
+	Alloc    uint64          // bytes allocated while the games were played
+	Heap     uint64          // bytes in use by the heap after the games, mostly the solver caches
+}
+
+// RunBenchmark plays a game for each answer with workers games at a time.  done is called with each game as it
+// finishes, one call at a time so it can write the game out.
+func RunBenchmark(wordLists *WordLists, strategy Strategy, answers []string, firstGuess string, maxGuesses int, workers int, done func(BenchmarkGame)) BenchmarkResult {
+	ret, _ := RunBenchmarkContext(context.Background(), wordLists, strategy, answers, firstGuess, maxGuesses, workers, done)
+	return ret
+}
+
+// RunBenchmarkContext is RunBenchmark that stops starting games when the context is cancelled.  The games being
+// played are finished, the games that were not played are left empty and the error is the context's.
+func RunBenchmarkContext(ctx context.Context, wordLists *WordLists, strategy Strategy, answers []string, firstGuess string, maxGuesses int, workers int, done func(BenchmarkGame)) (BenchmarkResult, error) {
+	if workers < 1 {
+		workers = 1
+	}
+	ret := BenchmarkResult{Strategy: strategy.Name, Games: make([]BenchmarkGame, len(answers))}
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	next := make(chan int)
+	var wg sync.WaitGroup
+	var doneLock sync.Mutex
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				gameStart := time.Now()
+				game := SimulateStrategy(wordLists, strategy.BestGuess, answers[i], firstGuess, maxGuesses)
+				ret.Games[i] = BenchmarkGame{game, time.Since(gameStart)}
+				if done != nil {
+					doneLock.Lock()
+					done(ret.Games[i])
+					doneLock.Unlock()
+				}
+			}
+		}()
+	}
+dispatch:
+	for i := range answers {
+		select {
+		case next <- i:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(next)
+	wg.Wait()
+	ret.Duration = time.Since(start)
+	runtime.ReadMemStats(&after)
+	ret.Alloc = after.TotalAlloc - before.TotalAlloc
+	ret.Heap = after.HeapAlloc
+	return ret, ctx.Err()
+}
+
+// Failed are the games that were not solved
+func (br BenchmarkResult) Failed() []BenchmarkGame {
+	ret := []BenchmarkGame{}
+	for _, game := range br.Games {
+		if !game.Solved {
+			ret = append(ret, game)
+		}
+	}
+	return ret
+}
+
+// Average is the average guesses of the solved games and the half width of the 95% confidence interval
+func (br BenchmarkResult) Average() (float64, float64) {
+	n, sum, sumSquares := 0.0, 0.0, 0.0
+	for _, game := range br.Games {
+		if game.Solved {
+			turns := float64(len(game.Guesses))
+			n++
+			sum += turns
+			sumSquares += turns * turns
+		}
+	}
+	if n == 0 {
+		return 0, 0
+	}
+	mean := sum / n
+	if n == 1 {
+		return mean, 0
+	}
+	variance := (sumSquares - n*mean*mean) / (n - 1)
+	return mean, 1.96 * math.Sqrt(math.Max(variance, 0)/n)
+}
+
+// Distribution is the number of solved games for each number of guesses
+func (br BenchmarkResult) Distribution() map[int]int {
+	ret := make(map[int]int)
+	for _, game := range br.Games {
+		if game.Solved {
+			ret[len(game.Guesses)]++
+		}
+	}
+	return ret
+}
+
+// Worst are the n games with the most guesses, failed games first
+func (br BenchmarkResult) Worst(n int) []BenchmarkGame {
+	ret := make([]BenchmarkGame, len(br.Games))
+	copy(ret, br.Games)
+	sort.SliceStable(ret, func(i, j int) bool {
+		if ret[i].Solved != ret[j].Solved {
+			return !ret[i].Solved
+		}
+		return len(ret[i].Guesses) > len(ret[j].Guesses)
+	})
+	return ret[:min(n, len(ret))]
+}
+
+// TimePerGame is the average time to play a game
+func (br BenchmarkResult) TimePerGame() time.Duration {
+	if len(br.Games) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, game := range br.Games {
+		total += game.Duration
+	}
+	return total / time.Duration(len(br.Games))
+}
+
+// Disagreements are the indexes of the answers where the strategies did not take the same number of guesses or
+// one of them failed.  The results must be for the same answers.
+func Disagreements(results []BenchmarkResult) []int {
+	ret := []int{}
+	if len(results) == 0 {
+		return ret
+	}
+	for i, game := range results[0].Games {
+		for _, result := range results[1:] {
+			other := result.Games[i]
+			if other.Solved != game.Solved || len(other.Guesses) != len(game.Guesses) {
+				ret = append(ret, i)
+				break
+			}
+		}
+	}
+	return ret
+}
@@ -0,0 +1,121 @@
+package gowordle
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+/*
+A checkpoint file keeps the games of a long simulation as they finish so a simulation that was stopped can be
+resumed.  It is JSON lines, the first line is the CheckpointHeader and each line after is a SimulateResult.
+The header must match to resume, games played with other words or another strategy would not add up.
+*/
+
+type CheckpointHeader struct {
+	Words      string `json:"words"` // WordLists.Hash
+	FirstGuess string `json:"first_guess"`
+	MaxGuesses int    `json:"max_guesses"`
+	Strategy   string `json:"strategy"`
+}
+
+type Checkpoint struct {
+	Header CheckpointHeader
+	Games  map[string]SimulateResult // finished games by answer
+	mu     sync.Mutex
+	file   *os.File
+}
+
+// OpenCheckpoint reads the games finished in an earlier run and opens the file to add more, a new file starts with
+// the header.  It is an error if the file is for a different header.
+func OpenCheckpoint(path string, header CheckpointHeader) (*Checkpoint, error) {
+	ret := &Checkpoint{Header: header, Games: make(map[string]SimulateResult)}
+	headerRead := false
+	in, err := os.Open(path)
+	if err == nil {
+		headerRead, err = ret.read(in)
+		in.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if ret.file, err = appendLines(path); err != nil {
+		return nil, err
+	}
+	if !headerRead {
+		if err := ret.writeLine(header); err != nil {
+			ret.file.Close()
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// read checks the header and reads the games, a partial last line is skipped.  False if there is no header, the
+// file is empty or only has empty lines.
+func (c *Checkpoint) read(in io.Reader) (bool, error) {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	headerRead := false
+	var partial error
+	for scanner.Scan() {
+		line++
+		if partial != nil {
+			return headerRead, partial
+		}
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		if !headerRead {
+			// the header is the first line that is not empty
+			headerRead = true
+			header := CheckpointHeader{}
+			if err := json.Unmarshal([]byte(text), &header); err != nil {
+				return headerRead, fmt.Errorf("line %d: %w", line, err)
+			}
+			if header != c.Header {
+				return headerRead, fmt.Errorf("checkpoint is for %+v, not %+v", header, c.Header)
+			}
+			continue
+		}
+		game := SimulateResult{}
+		if err := json.Unmarshal([]byte(text), &game); err != nil {
+			partial = fmt.Errorf("line %d: %w", line, err)
+			continue
+		}
+		c.Games[game.Answer] = game
+	}
+	return headerRead, scanner.Err()
+}
+
+func (c *Checkpoint) writeLine(value any) error {
+	line, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	_, err = c.file.Write(append(line, '\n'))
+	return err
+}
+
+// Add writes a finished game to the file right away so it is not lost if the run is stopped
+func (c *Checkpoint) Add(game SimulateResult) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Games[game.Answer] = game
+	return c.writeLine(game)
+}
+
+func (c *Checkpoint) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.file.Close()
+}
@@ -0,0 +1,210 @@
+package gowordle
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+/*
+Dictionary audit and diff.  The audit reads the entries as they are in the file, before they are normalized, and
+reports anything LoadDictionary would reject or quietly fix along with words that look unusual for wordle.
+The diff compares two dictionaries and how each added or removed answer moves the best first guess.
+*/
+
+type AuditFinding struct {
+	Line    int
+	Word    string // as it is in the file
+	Problem string
+}
+
+// AuditDictionaryFile audits a file, - is stdin, or a built in dictionary like builtin:answers
+func AuditDictionaryFile(path string) ([]AuditFinding, error) {
+	in, format, err := openDictionary(path)
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+	entries, err := readDictionaryEntries(in, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return auditEntries(entries), nil
+}
+
+func auditEntries(entries []dictionaryEntry) []AuditFinding {
+	ret := []AuditFinding{}
+	firstLine := make(map[string]int, len(entries))
+	for _, entry := range entries {
+		word := strings.TrimSpace(entry.word)
+		report := func(format string, a ...any) {
+			ret = append(ret, AuditFinding{Line: entry.line, Word: entry.word, Problem: fmt.Sprintf(format, a...)})
+		}
+		if length := utf8.RuneCountInString(word); length != 5 {
+			report("has %d letters", length)
+		}
+		valid := true
+		for _, r := range word {
+			switch {
+			case r > unicode.MaxASCII:
+				report("non-ASCII rune %q", r)
+			case r >= 'A' && r <= 'Z':
+				report("upper case %q", r)
+			case r < 'a' || r > 'z':
+				report("not a letter %q", r)
+			default:
+				continue
+			}
+			valid = false
+		}
+		normalized := strings.ToLower(word)
+		if line, ok := firstLine[normalized]; ok {
+			report("duplicate of line %d", line)
+		} else {
+			firstLine[normalized] = entry.line
+		}
+		if valid && utf8.RuneCountInString(word) == 5 {
+			for _, problem := range unusualPattern(word) {
+				report("%s", problem)
+			}
+		}
+	}
+	return ret
+}
+
+// unusualPattern returns the reasons a 5 letter word looks unusual, nil if it looks fine
+func unusualPattern(word string) []string {
+	ret := []string{}
+	if !strings.ContainsAny(word, "aeiouy") {
+		ret = append(ret, "no vowels")
+	}
+	counts := make(map[rune]int, 5)
+	for _, r := range word {
+		counts[r]++
+	}
+	for _, r := range word {
+		if counts[r] >= 3 {
+			ret = append(ret, fmt.Sprintf("letter %c %d times", r, counts[r]))
+			counts[r] = 0 // report once
+		}
+	}
+	if i := strings.IndexRune(word, 'q'); i >= 0 && (i == len(word)-1 || word[i+1] != 'u') {
+		ret = append(ret, "q not followed by u")
+	}
+	if len(ret) == 0 {
+		return nil
+	}
+	return ret
+}
+
+type FirstGuessShift struct {
+	Word        string
+	Added       bool   // true if added to the dictionary, false if removed
+	Best        string // best first guess for the first dictionary with only this change
+	Score       int    // GuessScore of Best
+	BestChanged bool   // Best is not the best first guess of the first dictionary
+}
+
+type DictionaryDiff struct {
+	Added   []string // in b but not a
+	Removed []string // in a but not b
+	BestA   string   // best first guess for a
+	ScoreA  int
+	BestB   string // best first guess for b
+	ScoreB  int
+	Shifts  []FirstGuessShift // one for each added and removed word
+}
+
+// DiffDictionaries compares the answers a and b.  The first guesses are chosen from guesses along with the words in
+// a and b and scored with GuessScore, ties go to the first guess.
+func DiffDictionaries(guesses []WordleWord, a, b []string) DictionaryDiff {
+	ret := DictionaryDiff{Added: ExcludeUsed(b, a), Removed: ExcludeUsed(a, b)}
+	allGuesses := NewWordLists(append(append([]string{}, a...), b...), WordleWordsToStrings(guesses)).Guesses
+	scorer := newFirstGuessScorer(allGuesses, StringsToWordleWords(a))
+	var best WordleWord
+	best, ret.ScoreA = scorer.best()
+	ret.BestA = string(best[:])
+	changes := make([]FirstGuessShift, 0, len(ret.Added)+len(ret.Removed))
+	for _, word := range ret.Added {
+		changes = append(changes, FirstGuessShift{Word: word, Added: true})
+	}
+	for _, word := range ret.Removed {
+		changes = append(changes, FirstGuessShift{Word: word, Added: false})
+	}
+	for _, change := range changes {
+		word := WordleWord([]rune(change.Word))
+		scorer.apply(word, change.Added)
+		best, change.Score = scorer.best()
+		change.Best = string(best[:])
+		change.BestChanged = change.Best != ret.BestA
+		scorer.apply(word, !change.Added)
+		ret.Shifts = append(ret.Shifts, change)
+	}
+	for _, change := range changes {
+		scorer.apply(WordleWord([]rune(change.Word)), change.Added)
+	}
+	best, ret.ScoreB = scorer.best()
+	ret.BestB = string(best[:])
+	return ret
+}
+
+// firstGuessScorer keeps the answer color bucket sizes for every guess so the GuessScore of each guess can be
+// updated when one answer is added or removed instead of scoring every guess against every answer again.
+// GuessScore is the sum of the squares of the bucket sizes, less 2 if the guess is an answer.
+type firstGuessScorer struct {
+	guesses []WordleWord
+	buckets []map[WordleWord]int // buckets[i] answer colors to number of answers for guesses[i]
+	squares []int                // squares[i] sum of the squares of the bucket sizes for guesses[i]
+	answers map[WordleWord]bool
+}
+
+func newFirstGuessScorer(guesses, answers []WordleWord) *firstGuessScorer {
+	ret := &firstGuessScorer{
+		guesses: guesses,
+		buckets: make([]map[WordleWord]int, len(guesses)),
+		squares: make([]int, len(guesses)),
+		answers: make(map[WordleWord]bool, len(answers)),
+	}
+	for i := range guesses {
+		ret.buckets[i] = make(map[WordleWord]int)
+	}
+	for _, answer := range answers {
+		ret.apply(answer, true)
+	}
+	return ret
+}
+
+// apply adds or removes the answer
+func (fs *firstGuessScorer) apply(answer WordleWord, add bool) {
+	fs.answers[answer] = add
+	for i, guess := range fs.guesses {
+		colors := WordleAnswer(answer, guess)
+		size := fs.buckets[i][colors]
+		if add {
+			fs.squares[i] += 2*size + 1
+			fs.buckets[i][colors] = size + 1
+		} else {
+			fs.squares[i] -= 2*size - 1
+			fs.buckets[i][colors] = size - 1
+		}
+	}
+}
+
+func (fs *firstGuessScorer) score(i int) int {
+	if fs.answers[fs.guesses[i]] && fs.squares[i] >= 2 {
+		return fs.squares[i] - 2
+	}
+	return fs.squares[i]
+}
+
+// best is the guess with the lowest score
+func (fs *firstGuessScorer) best() (WordleWord, int) {
+	bestIndex := 0
+	for i := range fs.guesses {
+		if fs.score(i) < fs.score(bestIndex) {
+			bestIndex = i
+		}
+	}
+	return fs.guesses[bestIndex], fs.score(bestIndex)
+}
@@ -0,0 +1,250 @@
+package gowordle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+/*
+Dictionaries are read from plain text, CSV or JSON:
+
+	text: one word per line, blank lines and lines starting with # are skipped
+	csv:  word,weight per line, the weight is optional and a "word,weight" header is skipped
+	json: ["cigar", "rebut"] or {"cigar": 1.5, "rebut": 0.2}
+
+Words are lower cased, anything that is not 5 letters a-z is an error that includes the line number
+and duplicates are dropped keeping the first.
+*/
+
+const (
+	DictionaryText = "text"
+	DictionaryCSV  = "csv"
+	DictionaryJSON = "json"
+)
+
+type Dictionary struct {
+	Words   []string           // in the order they were read
+	Weights map[string]float64 // weight of each word, nil if the source had no weights
+	Hash    string             // sha256 of the words, changes when the words or their order change
+}
+
+// dictionaryEntry is a word as it was read, before it is normalized and validated
+type dictionaryEntry struct {
+	line      int
+	word      string
+	weight    float64
+	hasWeight bool
+}
+
+// newDictionary normalizes and validates the entries, drops the duplicates and hashes the words
+func newDictionary(entries []dictionaryEntry) (*Dictionary, error) {
+	ret := &Dictionary{Words: []string{}}
+	seen := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		word := strings.ToLower(strings.TrimSpace(entry.word))
+		if _, err := ParseGuess(word); err != nil {
+			return nil, fmt.Errorf("line %d: %w", entry.line, err)
+		}
+		if seen[word] {
+			continue
+		}
+		seen[word] = true
+		ret.Words = append(ret.Words, word)
+		if entry.hasWeight {
+			if ret.Weights == nil {
+				ret.Weights = make(map[string]float64)
+			}
+			ret.Weights[word] = entry.weight
+		}
+	}
+	if len(ret.Words) == 0 {
+		return nil, errors.New("dictionary has no words")
+	}
+	ret.Hash = HashWords(ret.Words)
+	return ret, nil
+}
+
+// HashWords is the sha256 of the words in order, used to key anything computed from a word list
+func HashWords(words []string) string {
+	hash := sha256.New()
+	for _, word := range words {
+		hash.Write([]byte(word))
+		hash.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// DictionaryFormat is the format for the file extension: .csv, .json, everything else is text
+func DictionaryFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return DictionaryCSV
+	case ".json":
+		return DictionaryJSON
+	}
+	return DictionaryText
+}
+
+// BuiltinPrefix names one of the built in dictionaries instead of a file: builtin:answers, builtin:answers_orig
+// and builtin:guesses
+const BuiltinPrefix = "builtin:"
+
+// openDictionary opens a file, a built in dictionary or stdin for - and returns the format of the contents
+func openDictionary(path string) (io.ReadCloser, string, error) {
+	if path == "-" {
+		return io.NopCloser(os.Stdin), DictionaryText, nil
+	}
+	if name, ok := strings.CutPrefix(path, BuiltinPrefix); ok {
+		in, err := dictionaries.Open("dictionaries/" + name + ".txt")
+		if err != nil {
+			return nil, "", fmt.Errorf("no built in dictionary %s", name)
+		}
+		return in, DictionaryText, nil
+	}
+	in, err := os.Open(path)
+	if err != nil {
+		return nil, "", err
+	}
+	return in, DictionaryFormat(path), nil
+}
+
+// LoadDictionaryFile reads the dictionary in the format of the file extension, - is stdin as text and
+// builtin:name is one of the built in dictionaries
+func LoadDictionaryFile(path string) (*Dictionary, error) {
+	in, format, err := openDictionary(path)
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+	ret, err := LoadDictionary(in, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return ret, nil
+}
+
+// LoadDictionary reads a dictionary in one of the formats DictionaryText, DictionaryCSV or DictionaryJSON
+func LoadDictionary(in io.Reader, format string) (*Dictionary, error) {
+	entries, err := readDictionaryEntries(in, format)
+	if err != nil {
+		return nil, err
+	}
+	return newDictionary(entries)
+}
+
+func readDictionaryEntries(in io.Reader, format string) ([]dictionaryEntry, error) {
+	switch format {
+	case DictionaryText:
+		return readDictionaryText(in)
+	case DictionaryCSV:
+		return readDictionaryCSV(in)
+	case DictionaryJSON:
+		return readDictionaryJSON(in)
+	}
+	return nil, fmt.Errorf("unknown dictionary format %q", format)
+}
+
+func readDictionaryText(in io.Reader) ([]dictionaryEntry, error) {
+	text, err := io.ReadAll(in)
+	if err != nil {
+		return nil, err
+	}
+	ret := []dictionaryEntry{}
+	for i, line := range strings.Split(string(text), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		ret = append(ret, dictionaryEntry{line: i + 1, word: line})
+	}
+	return ret, nil
+}
+
+func readDictionaryCSV(in io.Reader) ([]dictionaryEntry, error) {
+	reader := csv.NewReader(in)
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+	ret := []dictionaryEntry{}
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		if first && strings.EqualFold(strings.TrimSpace(record[0]), "word") {
+			continue // header
+		}
+		switch len(record) {
+		case 1:
+			ret = append(ret, dictionaryEntry{line: line, word: record[0]})
+		case 2:
+			weight, err := strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: weight %q is not a number", line, record[1])
+			}
+			ret = append(ret, dictionaryEntry{line: line, word: record[0], weight: weight, hasWeight: true})
+		default:
+			return nil, fmt.Errorf("line %d: expected word,weight but found %d fields", line, len(record))
+		}
+	}
+	return ret, nil
+}
+
+func readDictionaryJSON(in io.Reader) ([]dictionaryEntry, error) {
+	text, err := io.ReadAll(in)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(text))
+	lineAt := func() int {
+		return bytes.Count(text[:decoder.InputOffset()], []byte{'\n'}) + 1
+	}
+	ret := []dictionaryEntry{}
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, fmt.Errorf("line %d: %w", lineAt(), err)
+	}
+	switch token {
+	case json.Delim('['):
+		for decoder.More() {
+			var word string
+			if err := decoder.Decode(&word); err != nil {
+				return nil, fmt.Errorf("line %d: expected a word: %w", lineAt(), err)
+			}
+			ret = append(ret, dictionaryEntry{line: lineAt(), word: word})
+		}
+	case json.Delim('{'):
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineAt(), err)
+			}
+			line := lineAt()
+			var weight float64
+			if err := decoder.Decode(&weight); err != nil {
+				return nil, fmt.Errorf("line %d: expected a weight: %w", lineAt(), err)
+			}
+			ret = append(ret, dictionaryEntry{line: line, word: key.(string), weight: weight, hasWeight: true})
+		}
+	default:
+		return nil, fmt.Errorf("line %d: expected a list of words or an object of word weights", lineAt())
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, fmt.Errorf("line %d: %w", lineAt(), err)
+	}
+	return ret, nil
+}
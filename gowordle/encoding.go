@@ -0,0 +1,118 @@
+package gowordle
+
+/*
+Words and feedback as integers for the hot paths, they make cheap map keys and compare in one instruction:
+
+	WordCode      5 bits per letter, a is 0 and z is 25, the first letter in the high bits so codes sort like words
+	FeedbackCode  base 3 digits like the digits notation, green 2 yellow 1 red 0, the first spot is the most
+	              significant digit so rrrrr is 0 and ggggg is 242
+
+Words are lower case a to z, other letters do not have a code.
+*/
+
+type WordCode uint32
+
+// letterBits is the bits for a letter in a WordCode
+const letterBits = 5
+
+func EncodeWord(word WordleWord) WordCode {
+	ret := WordCode(0)
+	for _, letter := range word {
+		ret = ret<<letterBits | WordCode(letter-'a')
+	}
+	return ret
+}
+
+// Letter is the letter in the spot, 0 is a and 25 is z
+func (c WordCode) Letter(spot int) int {
+	return int(c>>(letterBits*(4-spot))) & (1<<letterBits - 1)
+}
+
+func (c WordCode) Word() WordleWord {
+	ret := WordleWord{}
+	for spot := range ret {
+		ret[spot] = rune('a' + c.Letter(spot))
+	}
+	return ret
+}
+
+func (c WordCode) String() string {
+	word := c.Word()
+	return string(word[:])
+}
+
+// EncodeWords are the codes of the words in the same order
+func EncodeWords(words []WordleWord) []WordCode {
+	ret := make([]WordCode, len(words))
+	for i, word := range words {
+		ret[i] = EncodeWord(word)
+	}
+	return ret
+}
+
+type FeedbackCode uint8
+
+// FeedbackCodes is the number of different feedback codes, 3 to the 5th
+const FeedbackCodes = 243
+
+// AllGreenCode is ggggg
+const AllGreenCode FeedbackCode = FeedbackCodes - 1
+
+// feedbackColors are the colors of each base 3 digit
+var feedbackColors = [3]rune{'r', 'y', 'g'}
+
+// EncodeFeedback is the code of g, y and r colors
+func EncodeFeedback(colors WordleWord) FeedbackCode {
+	ret := FeedbackCode(0)
+	for _, color := range colors {
+		ret *= 3
+		switch color {
+		case 'g':
+			ret += 2
+		case 'y':
+			ret++
+		}
+	}
+	return ret
+}
+
+// Colors are the g, y and r colors of the code
+func (c FeedbackCode) Colors() WordleWord {
+	ret := WordleWord{}
+	for spot := 4; spot >= 0; spot-- {
+		ret[spot] = feedbackColors[c%3]
+		c /= 3
+	}
+	return ret
+}
+
+func (c FeedbackCode) String() string {
+	colors := c.Colors()
+	return string(colors[:])
+}
+
+// Feedback is the code of the colors for the guess when the answer is the solution, the same colors as
+// WordleAnswer without building an Answer
+func Feedback(solution, guess WordCode) FeedbackCode {
+	notGreen := [26]int8{} // letters of the solution that are not green
+	digits := [5]FeedbackCode{}
+	for spot := 0; spot < 5; spot++ {
+		if letter := solution.Letter(spot); letter == guess.Letter(spot) {
+			digits[spot] = 2
+		} else {
+			notGreen[letter]++
+		}
+	}
+	// the first not green copies of a letter are yellow, like WordleAnswer2
+	ret := FeedbackCode(0)
+	for spot := 0; spot < 5; spot++ {
+		if digits[spot] == 0 {
+			if letter := guess.Letter(spot); notGreen[letter] > 0 {
+				notGreen[letter]--
+				digits[spot] = 1
+			}
+		}
+		ret = ret*3 + digits[spot]
+	}
+	return ret
+}
@@ -0,0 +1,408 @@
+package gowordle
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+The engine protocol lets programs in any language play wordle, like UCI for chess engines.  The driver writes
+commands to the engine's stdin one per line and reads the replies from its stdout:
+
+	driver                     engine
+	wdl                        id name <name>
+	                           wdlok
+	isready                    readyok
+	dict answers <word>...     the possible answers, the engine starts with its own
+	dict guesses <word>...     the allowed guesses along with the answers
+	newgame                    forget the guesses
+	guess <word> <feedback>    a guess of the game and its feedback, g y and r
+	go [milliseconds]          bestmove <word>, the time is how long the engine can take, without it no limit
+	quit
+
+The engine sends "error <message>" for a command it can not follow and carries on, other lines it does not expect
+are ignored by the driver.  An Engine that runs out of time plays the first possible answer.  Engine runs the protocol for a strategy and EngineClient drives an engine process.
+*/
+
+// EngineProtocol is the first command, like uci
+const EngineProtocol = "wdl"
+
+type Engine struct {
+	Name     string
+	Strategy Strategy
+	session  *Session
+	answers  []string // replaced by dict
+	guesses  []string
+}
+
+func NewEngine(words *WordLists, firstGuess WordleWord, strategy Strategy) *Engine {
+	return &Engine{Name: "wdl " + strategy.Name, Strategy: strategy, session: NewSession(words, firstGuess),
+		answers: WordleWordsToStrings(words.Answers), guesses: WordleWordsToStrings(words.Guesses)}
+}
+
+// bestMove is the first guess before any guesses, otherwise the strategy's guess.  With a move time the strategy
+// runs until the time is up, then the first possible answer is played and the strategy finishes in the background
+// filling the caches.  0 waits for the strategy.
+func (e *Engine) bestMove(moveTime time.Duration) WordleWord {
+	if len(e.session.History) == 0 {
+		return e.session.FirstGuess
+	}
+	guesses, possible := e.session.Words.GuessList(), e.session.Possible()
+	if moveTime == 0 {
+		return nextGuess(e.Strategy.BestGuess, guesses, possible)
+	}
+	best := make(chan WordleWord, 1)
+	go func() {
+		best <- nextGuess(e.Strategy.BestGuess, guesses, possible)
+	}()
+	deadline := time.NewTimer(moveTime)
+	defer deadline.Stop()
+	select {
+	case guess := <-best:
+		return guess
+	case <-deadline.C:
+		return possible[0]
+	}
+}
+
+// command follows one command and returns the reply lines, quit is true for quit
+func (e *Engine) command(line string) (reply []string, quit bool) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil, false
+	}
+	switch fields[0] {
+	case EngineProtocol:
+		return []string{"id name " + e.Name, "wdlok"}, false
+	case "isready":
+		return []string{"readyok"}, false
+	case "dict":
+		if len(fields) < 3 || (fields[1] != "answers" && fields[1] != "guesses") {
+			return []string{"error dict answers|guesses <word>..."}, false
+		}
+		for _, word := range fields[2:] {
+			if _, err := ParseGuess(word); err != nil {
+				return []string{"error " + err.Error()}, false
+			}
+		}
+		if fields[1] == "answers" {
+			e.answers = fields[2:]
+		} else {
+			e.guesses = fields[2:]
+		}
+		e.session.Words = NewWordLists(e.answers, e.guesses)
+		e.session.Reset()
+		return nil, false
+	case "newgame":
+		e.session.Reset()
+		return nil, false
+	case "guess":
+		if len(fields) != 3 {
+			return []string{"error guess <word> <feedback>"}, false
+		}
+		guess, err := ParseGuess(fields[1])
+		if err != nil {
+			return []string{"error " + err.Error()}, false
+		}
+		feedback, err := ParseFeedback(fields[2])
+		if err != nil {
+			return []string{"error " + err.Error()}, false
+		}
+		if err := e.session.Apply(guess, feedback); err != nil {
+			return []string{"error " + err.Error()}, false
+		}
+		return nil, false
+	case "go":
+		moveTime := time.Duration(0)
+		if len(fields) > 1 {
+			milliseconds, err := strconv.Atoi(fields[1])
+			if err != nil || milliseconds < 1 || len(fields) > 2 {
+				return []string{"error go [milliseconds]"}, false
+			}
+			moveTime = time.Duration(milliseconds) * time.Millisecond
+		}
+		guess := e.bestMove(moveTime)
+		return []string{"bestmove " + string(guess[:])}, false
+	case "quit":
+		return nil, true
+	}
+	return []string{"error unknown command " + fields[0]}, false
+}
+
+// Run reads commands from in and writes the replies to out until quit or the end of in
+func (e *Engine) Run(in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024) // dict lines are long
+	for scanner.Scan() {
+		reply, quit := e.command(scanner.Text())
+		for _, line := range reply {
+			if _, err := fmt.Fprintln(out, line); err != nil {
+				return err
+			}
+		}
+		if quit {
+			return nil
+		}
+	}
+	return scanner.Err()
+}
+
+// EngineClient drives an engine process
+type EngineClient struct {
+	Name    string // from id name, the command if the engine did not send one
+	Timeout time.Duration
+	cmd     *exec.Cmd
+	in      io.WriteCloser
+	lines   chan string // stdout, closed when the engine exits
+}
+
+// EngineTimeout is how long an engine has to reply to the other commands and how much longer than the time given to
+// go it has
+var EngineTimeout = 10 * time.Second
+
+// StartEngine starts the engine process and waits for wdlok
+func StartEngine(command []string) (*EngineClient, error) {
+	if len(command) == 0 {
+		return nil, fmt.Errorf("no engine command")
+	}
+	ret := &EngineClient{Name: strings.Join(command, " "), Timeout: EngineTimeout, cmd: exec.Command(command[0], command[1:]...)}
+	var err error
+	if ret.in, err = ret.cmd.StdinPipe(); err != nil {
+		return nil, err
+	}
+	out, err := ret.cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := ret.cmd.Start(); err != nil {
+		return nil, err
+	}
+	ret.lines = make(chan string, 16)
+	go func() {
+		scanner := bufio.NewScanner(out)
+		for scanner.Scan() {
+			ret.lines <- scanner.Text()
+		}
+		close(ret.lines)
+	}()
+	if err := ret.send(EngineProtocol); err != nil {
+		ret.Close()
+		return nil, err
+	}
+	for {
+		line, err := ret.readLine(ret.Timeout)
+		if err != nil {
+			ret.Close()
+			return nil, err
+		}
+		if name, ok := strings.CutPrefix(line, "id name "); ok {
+			ret.Name = name
+		} else if line == "wdlok" {
+			return ret, nil
+		}
+	}
+}
+
+func (c *EngineClient) send(line string) error {
+	_, err := io.WriteString(c.in, line+"\n")
+	return err
+}
+
+// readLine waits for the next line from the engine, up to the timeout if it is not 0
+func (c *EngineClient) readLine(timeout time.Duration) (string, error) {
+	var expired <-chan time.Time
+	if timeout != 0 {
+		expired = time.After(max(timeout, time.Nanosecond))
+	}
+	select {
+	case line, ok := <-c.lines:
+		if !ok {
+			return "", fmt.Errorf("engine %s exited", c.Name)
+		}
+		return line, nil
+	case <-expired:
+		return "", fmt.Errorf("engine %s did not reply in time", c.Name)
+	}
+}
+
+// expect reads lines until one starts with the prefix and returns the rest of it, an error line from the engine
+// is an error.  A timeout of 0 waits as long as it takes.
+func (c *EngineClient) expect(prefix string, timeout time.Duration) (string, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		remaining := time.Duration(0)
+		if timeout != 0 {
+			remaining = time.Until(deadline)
+		}
+		line, err := c.readLine(remaining)
+		if err != nil {
+			return "", err
+		}
+		if message, ok := strings.CutPrefix(line, "error "); ok {
+			return "", fmt.Errorf("engine %s: %s", c.Name, message)
+		}
+		if rest, ok := strings.CutPrefix(line, prefix); ok {
+			return rest, nil
+		}
+	}
+}
+
+// SetWordLists sends the answers and guesses and waits for the engine to be ready
+func (c *EngineClient) SetWordLists(words *WordLists) error {
+	if err := c.send("dict answers " + strings.Join(WordleWordsToStrings(words.Answers), " ")); err != nil {
+		return err
+	}
+	if err := c.send("dict guesses " + strings.Join(WordleWordsToStrings(words.Guesses), " ")); err != nil {
+		return err
+	}
+	return c.IsReady()
+}
+
+func (c *EngineClient) IsReady() error {
+	if err := c.send("isready"); err != nil {
+		return err
+	}
+	_, err := c.expect("readyok", c.Timeout)
+	return err
+}
+
+func (c *EngineClient) NewGame() error {
+	return c.send("newgame")
+}
+
+func (c *EngineClient) Guess(guess, feedback WordleWord) error {
+	return c.send("guess " + string(guess[:]) + " " + string(feedback[:]))
+}
+
+// Go asks for the engine's guess.  The engine has the moveTime and the Timeout to reply, a moveTime of 0 is no
+// time limit.
+func (c *EngineClient) Go(moveTime time.Duration) (WordleWord, error) {
+	command, timeout := "go", time.Duration(0)
+	if moveTime > 0 {
+		command += " " + strconv.FormatInt(moveTime.Milliseconds(), 10)
+		timeout = moveTime + c.Timeout
+	}
+	if err := c.send(command); err != nil {
+		return WordleWord{}, err
+	}
+	move, err := c.expect("bestmove ", timeout)
+	if err != nil {
+		return WordleWord{}, err
+	}
+	return ParseGuess(strings.TrimSpace(move))
+}
+
+// Close sends quit and waits for the engine to exit
+func (c *EngineClient) Close() error {
+	c.send("quit")
+	c.in.Close()
+	done := make(chan error, 1)
+	go func() { done <- c.cmd.Wait() }()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(c.Timeout):
+		c.cmd.Process.Kill()
+		return <-done
+	}
+}
+
+// PlayEngine plays a game for the answer with the engine, a guess that is not in the guess list is an error
+func PlayEngine(c *EngineClient, words *WordLists, answer string, maxGuesses int, moveTime time.Duration) (SimulateResult, error) {
+	ret := SimulateResult{Answer: answer, Guesses: []string{}, Colors: []string{}}
+	if err := c.NewGame(); err != nil {
+		return ret, err
+	}
+	solution := WordleWord([]rune(answer))
+	for len(ret.Guesses) < maxGuesses {
+		guess, err := c.Go(moveTime)
+		if err != nil {
+			return ret, err
+		}
+		if !words.ValidGuess(guess) {
+			return ret, fmt.Errorf("engine %s guessed %s, it is not in the guess list", c.Name, string(guess[:]))
+		}
+		feedback := WordleAnswer(solution, guess)
+		ret.Guesses = append(ret.Guesses, string(guess[:]))
+		ret.Colors = append(ret.Colors, string(feedback[:]))
+		if feedback == allGreen {
+			ret.Solved = true
+			break
+		}
+		if err := c.Guess(guess, feedback); err != nil {
+			return ret, err
+		}
+	}
+	return ret, nil
+}
+
+// RunArena plays a game for each answer with workers copies of the engine, like RunBenchmark.  The strategy of the
+// result is the engine's name.  The first error stops the arena.
+func RunArena(command []string, words *WordLists, answers []string, maxGuesses int, moveTime time.Duration, workers int, done func(BenchmarkGame)) (BenchmarkResult, error) {
+	if workers < 1 {
+		workers = 1
+	}
+	workers = min(workers, max(len(answers), 1))
+	engines := make([]*EngineClient, 0, workers)
+	defer func() {
+		for _, engine := range engines {
+			engine.Close()
+		}
+	}()
+	for w := 0; w < workers; w++ {
+		engine, err := StartEngine(command)
+		if err != nil {
+			return BenchmarkResult{}, err
+		}
+		engines = append(engines, engine)
+		if err := engine.SetWordLists(words); err != nil {
+			return BenchmarkResult{}, err
+		}
+	}
+	ret := BenchmarkResult{Strategy: engines[0].Name, Games: make([]BenchmarkGame, len(answers))}
+	start := time.Now()
+	next := make(chan int)
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	var firstError error
+	for _, engine := range engines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				gameStart := time.Now()
+				game, err := PlayEngine(engine, words, answers[i], maxGuesses, moveTime)
+				lock.Lock()
+				if err != nil && firstError == nil {
+					firstError = fmt.Errorf("%s: %w", answers[i], err)
+				}
+				if firstError == nil {
+					ret.Games[i] = BenchmarkGame{game, time.Since(gameStart)}
+					if done != nil {
+						done(ret.Games[i])
+					}
+				}
+				lock.Unlock()
+			}
+		}()
+	}
+	for i := range answers {
+		lock.Lock()
+		stop := firstError != nil
+		lock.Unlock()
+		if stop {
+			break
+		}
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	ret.Duration = time.Since(start)
+	return ret, firstError
+}
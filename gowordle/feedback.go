@@ -0,0 +1,145 @@
+package gowordle
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+/*
+Feedback is the answer colors for a guess.  Internally the colors are always g (green), y (yellow) and r (red)
+but people type them many ways:
+
+	gyrrr  GYRRR  gybbb  gyxxx  gy...  gy---  21000  🟩🟨⬛⬛⬛
+*/
+
+// FeedbackNotations are the named notations, the symbols for green, yellow and red in that order
+var FeedbackNotations = map[string]string{
+	"gyr":    "gyr",
+	"gyb":    "gyb", // b for black
+	"gyx":    "gyx",
+	"dots":   "gy.",
+	"dashes": "gy-",
+	"digits": "210", // 2 correct, 1 present, 0 absent
+	"emoji":  "🟩🟨⬛",
+}
+
+// defaultFeedbackSymbols are accepted by every parser, upper case letters are added by NewFeedbackParser
+var defaultFeedbackSymbols = map[rune]rune{
+	'g': 'g',
+	'y': 'y',
+	'r': 'r',
+	'b': 'r',
+	'x': 'r',
+	'.': 'r',
+	'-': 'r',
+	'_': 'r',
+	'2': 'g',
+	'1': 'y',
+	'0': 'r',
+}
+
+// feedbackIgnored are invisible runes that come along when emoji are copied
+var feedbackIgnored = map[rune]bool{
+	'\uFE0F': true, // variation selector
+	'\u200D': true, // zero width joiner
+}
+
+type FeedbackParser struct {
+	symbols map[rune]rune // symbol typed to g, y or r
+	output  [3]rune       // symbols used by Format for green, yellow and red
+}
+
+// NewFeedbackParser accepts all the notations in FeedbackNotations and formats with g, y and r
+func NewFeedbackParser() *FeedbackParser {
+	ret := &FeedbackParser{symbols: make(map[rune]rune), output: [3]rune{'g', 'y', 'r'}}
+	for symbol, color := range defaultFeedbackSymbols {
+		ret.symbols[symbol] = color
+		ret.symbols[[]rune(strings.ToUpper(string(symbol)))[0]] = color
+	}
+	for symbol, color := range shareGridColors {
+		ret.symbols[symbol] = color
+	}
+	return ret
+}
+
+// DefaultFeedback is the parser used by ParseFeedback and FormatFeedback
+var DefaultFeedback = NewFeedbackParser()
+
+// SetNotation makes the notation the one used by Format and adds its symbols, replacing any other meaning they had.
+// The notation is either a name from FeedbackNotations or three symbols for green, yellow and red, like "+?-"
+func (fp *FeedbackParser) SetNotation(notation string) error {
+	if named, ok := FeedbackNotations[notation]; ok {
+		notation = named
+	}
+	symbols := []rune(notation)
+	if len(symbols) != 3 {
+		return fmt.Errorf("notation %q must be a name or three symbols for green, yellow and red", notation)
+	}
+	if symbols[0] == symbols[1] || symbols[0] == symbols[2] || symbols[1] == symbols[2] {
+		return fmt.Errorf("notation %q must use a different symbol for each color", notation)
+	}
+	for i, color := range []rune{'g', 'y', 'r'} {
+		fp.symbols[symbols[i]] = color
+		fp.output[i] = symbols[i]
+	}
+	return nil
+}
+
+// Parse turns typed feedback into answer colors, unknown symbols and the wrong number of symbols are errors
+func (fp *FeedbackParser) Parse(feedback string) (WordleWord, error) {
+	colors := make([]rune, 0, 5)
+	for _, symbol := range strings.TrimSpace(feedback) {
+		if feedbackIgnored[symbol] {
+			continue
+		}
+		color, ok := fp.symbols[symbol]
+		if !ok {
+			return WordleWord{}, fmt.Errorf("feedback %q has unknown symbol %q", feedback, symbol)
+		}
+		colors = append(colors, color)
+	}
+	if len(colors) != 5 {
+		return WordleWord{}, fmt.Errorf("feedback %q has %d colors, expected 5", feedback, len(colors))
+	}
+	return WordleWord(colors), nil
+}
+
+// Format writes answer colors in the notation
+func (fp *FeedbackParser) Format(colors WordleWord) string {
+	var ret strings.Builder
+	for _, color := range colors {
+		switch color {
+		case 'g':
+			ret.WriteRune(fp.output[0])
+		case 'y':
+			ret.WriteRune(fp.output[1])
+		default:
+			ret.WriteRune(fp.output[2])
+		}
+	}
+	return ret.String()
+}
+
+// ParseFeedback parses feedback with DefaultFeedback
+func ParseFeedback(feedback string) (WordleWord, error) {
+	return DefaultFeedback.Parse(feedback)
+}
+
+// FormatFeedback formats answer colors with DefaultFeedback
+func FormatFeedback(colors WordleWord) string {
+	return DefaultFeedback.Format(colors)
+}
+
+// ParseGuess checks that the guess is 5 lower case letters
+func ParseGuess(guess string) (WordleWord, error) {
+	if utf8.RuneCountInString(guess) != 5 {
+		return WordleWord{}, fmt.Errorf("guess %q must be 5 letters", guess)
+	}
+	for _, letter := range guess {
+		if letter < 'a' || letter > 'z' {
+			return WordleWord{}, fmt.Errorf("guess %q must be lower case letters", guess)
+		}
+	}
+	return WordleWord([]rune(guess)), nil
+}
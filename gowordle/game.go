@@ -0,0 +1,110 @@
+package gowordle
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+Game hosts a game of wordle for a person: the answer is secret and each guess is checked against the guess list.
+In hard mode the hints revealed must be used, green letters stay in place and yellow letters are in the guess.
+*/
+
+type Game struct {
+	Words      *WordLists
+	Answer     WordleWord
+	MaxGuesses int
+	HardMode   bool
+	Guesses    []GuessAnswer
+}
+
+func NewGame(words *WordLists, answer WordleWord, maxGuesses int) *Game {
+	return &Game{Words: words, Answer: answer, MaxGuesses: maxGuesses, Guesses: []GuessAnswer{}}
+}
+
+// Guess returns the answer colors, an invalid word or a guess after the game is over is an error
+func (g *Game) Guess(guess WordleWord) (WordleWord, error) {
+	if g.Over() {
+		return WordleWord{}, fmt.Errorf("the game is over")
+	}
+	if !g.Words.ValidGuess(guess) {
+		return WordleWord{}, fmt.Errorf("%s is not in the word list", string(guess[:]))
+	}
+	if g.HardMode {
+		if err := g.hardModeCheck(guess); err != nil {
+			return WordleWord{}, err
+		}
+	}
+	colors := WordleAnswer(g.Answer, guess)
+	g.Guesses = append(g.Guesses, GuessAnswer{guess, colors})
+	return colors, nil
+}
+
+// hardModeCheck is an error if the guess does not use the hints of an earlier guess.  These are the green mismatch and
+// too few copies rules of Excluded, the other rules are allowed in hard mode.
+func (g *Game) hardModeCheck(guess WordleWord) error {
+	for _, ga := range g.Guesses {
+		exclusion, ok := Excluded(guess, ga.Guess, ga.Answer)
+		if !ok {
+			continue
+		}
+		switch exclusion.Rule {
+		case RuleGreenMismatch:
+			return fmt.Errorf("hard mode: spot %d must be %c", exclusion.Position, exclusion.Letter)
+		case RuleTooFew:
+			return fmt.Errorf("hard mode: guess must contain %d %c", exclusion.Count, exclusion.Letter)
+		}
+	}
+	return nil
+}
+
+// Solved is true once a guess was all green
+func (g *Game) Solved() bool {
+	return len(g.Guesses) > 0 && g.Guesses[len(g.Guesses)-1].Answer == allGreen
+}
+
+// Over is true if the game was solved or all the guesses were used
+func (g *Game) Over() bool {
+	return g.Solved() || len(g.Guesses) >= g.MaxGuesses
+}
+
+// Keyboard is the best color found for each guessed letter: g, y or r.  Letters not guessed are missing.
+func (g *Game) Keyboard() map[rune]rune {
+	rank := map[rune]int{'r': 1, 'y': 2, 'g': 3}
+	ret := make(map[rune]rune)
+	for _, ga := range g.Guesses {
+		for i, letter := range ga.Guess {
+			if color := ga.Answer[i]; rank[color] > rank[ret[letter]] {
+				ret[letter] = color
+			}
+		}
+	}
+	return ret
+}
+
+// ShareGrid is the grid players post after a game, it can be read back with ParseShareGrid.  The puzzle number is
+// left out if it is negative.  With highContrast the green and yellow squares are orange and blue.
+func (g *Game) ShareGrid(puzzle int, highContrast bool) string {
+	squares := map[rune]string{'g': "🟩", 'y': "🟨", 'r': "⬛"}
+	if highContrast {
+		squares['g'] = "🟧"
+		squares['y'] = "🟦"
+	}
+	result := "X"
+	if g.Solved() {
+		result = fmt.Sprint(len(g.Guesses))
+	}
+	var ret strings.Builder
+	ret.WriteString("Wordle ")
+	if puzzle >= 0 {
+		fmt.Fprintf(&ret, "%d ", puzzle)
+	}
+	fmt.Fprintf(&ret, "%s/%d\n\n", result, g.MaxGuesses)
+	for _, ga := range g.Guesses {
+		for _, color := range ga.Answer {
+			ret.WriteString(squares[color])
+		}
+		ret.WriteString("\n")
+	}
+	return ret.String()
+}
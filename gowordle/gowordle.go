@@ -152,9 +152,9 @@ type GuessAnswer struct {
 }
 
 // play wordle against the computer providing the current board state
-// return the next best answer
-func PlayWorldReturnPossible(allWordleWords []WordleWord, guessAnswers []GuessAnswer) (WordleWord, []WordleWord) {
-	possibleAnswers := allWordleWords
+// return the next best answer from allWordleWords and the possible answers remaining
+func PlayWorldReturnPossible(allWordleWords, possibleWords []WordleWord, guessAnswers []GuessAnswer) (WordleWord, []WordleWord) {
+	possibleAnswers := possibleWords
 
 	for _, guessAnswer := range guessAnswers {
 		game := NewWordleMatcher(possibleAnswers)
@@ -165,19 +165,32 @@ func PlayWorldReturnPossible(allWordleWords []WordleWord, guessAnswers []GuessAn
 	return ret, possibleAnswers
 }
 
-func PlayWordle(allWordleWords []WordleWord, guessAnswers []GuessAnswer) WordleWord {
-	ret, _ := PlayWorldReturnPossible(allWordleWords, guessAnswers)
+func PlayWordle(allWordleWords, possibleWords []WordleWord, guessAnswers []GuessAnswer) WordleWord {
+	ret, _ := PlayWorldReturnPossible(allWordleWords, possibleWords, guessAnswers)
 	return ret
 }
 
+// playStrategy is PlayWordle with the bestGuess strategy
+func playStrategy(bestGuess BestGuessFunc, wordLists *WordLists, guessAnswers []GuessAnswer) WordleWord {
+	possibleAnswers := wordLists.Answers
+	for _, guessAnswer := range guessAnswers {
+		possibleAnswers = NewWordleMatcher(possibleAnswers).Matching(guessAnswer.Guess, guessAnswer.Answer)
+	}
+	return nextGuess(bestGuess, wordLists.GuessList(), possibleAnswers)
+}
+
 func NextGuess1(allWords, possibleAnswers []WordleWord) WordleWord {
-	_, wordsPossible := BestGuess1(allWords, possibleAnswers, possibleAnswers, 1, len(possibleAnswers)+1)
+	return nextGuess(BestGuess1, NewGuessList(allWords), possibleAnswers)
+}
+
+func nextGuess(bestGuess BestGuessFunc, guesses GuessList, possibleAnswers []WordleWord) WordleWord {
+	_, wordsPossible := bestGuess(guesses, possibleAnswers, possibleAnswers, 1, len(possibleAnswers)+1)
 	return wordsPossible[0]
 }
 
 func FirstGuess1(allWords []string) (float32, []WordleWord) {
 	wws := StringsToWordleWords(allWords)
-	score, ret := BestGuess1(wws, wws, wws, 1, len(allWords))
+	score, ret := BestGuess1(NewGuessList(wws), wws, wws, 1, len(allWords))
 	return float32(score), ret
 }
 
@@ -185,7 +198,7 @@ func FirstGuessProvideInitialGuesses1(initialGuesses_s, allWords_s []string) (fl
 	allWords := StringsToWordleWords(allWords_s)
 	initialGuesses := StringsToWordleWords(initialGuesses_s)
 	// score, ret := BestGuess1(allWords, allWords, initialGuesses, 1, len(allwords))
-	score, ret := BestGuess1(allWords, allWords, initialGuesses, 1, 10)
+	score, ret := BestGuess1(NewGuessList(allWords), allWords, initialGuesses, 1, 10)
 	return float32(score), ret
 }
 
@@ -206,31 +219,17 @@ var matching2 bool = true
 var Logging bool = false
 var BetterGuesses map[string]int = make(map[string]int)
 
-type gameCache struct {
-	gameId int
-	score  int
-	words  []WordleWord
-}
-
-var gameCacheMap map[int]gameCache = make(map[int]gameCache)
-
-func scoreForPossibleWords(gameId int) (int, []WordleWord, bool) {
-	if ret, ok := gameCacheMap[gameId]; ok {
-		return ret.score, ret.words, true
+// scoreForPossibleWords is the remembered score and best guesses for the memo key
+func scoreForPossibleWords(key string) (int, []WordleWord, bool) {
+	if entry, ok := SolverMemo.Get(key); ok && len(entry.Scores) > 0 {
+		return entry.Scores[0], StringsToWordleWords(entry.Words), true
 	}
 	return 0, nil, false
 }
-func rememberScoreForPossibleWords(gameId int, score int, words []WordleWord) (int, []WordleWord) {
-	if _, ok := gameCacheMap[gameId]; ok {
-		panic("already have score for " + fmt.Sprintf("%d", gameId))
-	}
-	gameCacheMap[gameId] = gameCache{gameId, score, words}
-	return score, words
-}
 
 // find best next guess, return the low score and the slice of words that have that score
 // The score will be the average number of guesses it will take to solve if one the best guesses is used
-func ScoreAlgorithmRecursive(allWords, possibleWords, _initialGuesses []WordleWord, depth int, bestScoreSoFar int) (int, []WordleWord) {
+func ScoreAlgorithmRecursive(guesses GuessList, possibleWords, _initialGuesses []WordleWord, depth int, bestScoreSoFar int) (int, []WordleWord) {
 	const INIFINITY_SCORE = 1000000
 	if len(possibleWords) == 0 {
 		panic("possibleWords is empty")
@@ -245,12 +244,13 @@ func ScoreAlgorithmRecursive(allWords, possibleWords, _initialGuesses []WordleWo
 
 	// Using the possible words the best guess is the matching one for one solution and 2 for the rest of the solutions.
 	game := NewWordleMatcher(possibleWords)
-	if retScore, retWordsWithScore, ok := scoreForPossibleWords(game.id); ok {
+	key := memoKey(MemoRecursive, guesses.Hash, possibleWords)
+	if retScore, retWordsWithScore, ok := scoreForPossibleWords(key); ok {
 		return retScore, retWordsWithScore
 	}
-	possibleWordsSet := make(map[string]bool)
+	possibleWordsSet := make(map[WordCode]bool, len(possibleWords))
 	for _, guess := range possibleWords {
-		possibleWordsSet[string(guess[:])] = true
+		possibleWordsSet[EncodeWord(guess)] = true
 	}
 	guessesInPossibleWords := make([]WordleWord, 0)
 	guessesNotInPossibleWords := make([]WordleWord, 0)
@@ -258,11 +258,11 @@ func ScoreAlgorithmRecursive(allWords, possibleWords, _initialGuesses []WordleWo
 	if true {
 		maxGuessCount := 300
 		flagGuessCountCutOff = maxGuessCount - 100
-		sortedScores := ScoreAlgorithmTotalMatches1LevelAll(allWords, possibleWords, _initialGuesses, depth, bestScoreSoFar)
+		sortedScores := ScoreAlgorithmTotalMatches1LevelAll(guesses.Words, possibleWords, _initialGuesses, depth, bestScoreSoFar)
 		for guessCount := 0; (sortedScores.Len() > 0) && (guessCount < maxGuessCount); guessCount++ {
 			item := heap.Pop(sortedScores).(Item)
 			guess := item.Value
-			if _, ok := possibleWordsSet[string(guess[:])]; ok {
+			if possibleWordsSet[EncodeWord(guess)] {
 				guessesInPossibleWords = append(guessesInPossibleWords, guess)
 			} else {
 				guessesNotInPossibleWords = append(guessesNotInPossibleWords, guess)
@@ -270,8 +270,8 @@ func ScoreAlgorithmRecursive(allWords, possibleWords, _initialGuesses []WordleWo
 		}
 	} else {
 		flagGuessCountCutOff = 1000000
-		for _, guess := range allWords {
-			if _, ok := possibleWordsSet[string(guess[:])]; ok {
+		for _, guess := range guesses.Words {
+			if possibleWordsSet[EncodeWord(guess)] {
 				guessesInPossibleWords = append(guessesInPossibleWords, guess)
 			} else {
 				guessesNotInPossibleWords = append(guessesNotInPossibleWords, guess)
@@ -311,10 +311,10 @@ func ScoreAlgorithmRecursive(allWords, possibleWords, _initialGuesses []WordleWo
 			}
 			// Score the guess for this solution
 			guessSolutionScore := 100 // one guess is 100 points
-			if (len(matching) == 1) && (string(matching[0][:]) == string(guess[:])) {
+			if (len(matching) == 1) && (matching[0] == guess) {
 				guessInPossibleWordsRemaining = false // this is the correct guess
 			} else {
-				subscore, _ := ScoreAlgorithmRecursive(allWords, matching, matching, depth+1, bestScoreSoFar)
+				subscore, _ := ScoreAlgorithmRecursive(guesses, matching, matching, depth+1, bestScoreSoFar)
 				guessSolutionScore += subscore
 			}
 			score = score + ((guessSolutionScore - score) / (count + 1)) // running average
@@ -349,7 +349,7 @@ func ScoreAlgorithmRecursive(allWords, possibleWords, _initialGuesses []WordleWo
 			bestGuess = append(bestGuess, guess)
 		}
 	}
-	return rememberScoreForPossibleWords(game.id, bestScore, bestGuess)
+	return rememberBest(key, len(possibleWords), bestScore, bestGuess)
 }
 
 /*************
@@ -464,23 +464,36 @@ func ScoreAlgorithmRecursive_try1(allWords, possibleWords, _initialGuesses []Wor
 }
 ***************/
 
-func ScoreAlgorithmTotalMatches1Level(allWords, possibleWords, initialGuesses []WordleWord, depth int, bestScoreSoFar int) (int, []WordleWord) {
-	minHeap := ScoreAlgorithmTotalMatches1LevelAll(allWords, possibleWords, initialGuesses, depth, bestScoreSoFar)
+func ScoreAlgorithmTotalMatches1Level(guesses GuessList, possibleWords, initialGuesses []WordleWord, depth int, bestScoreSoFar int) (int, []WordleWord) {
+	if len(possibleWords) == 1 {
+		// nothing to remember, ScoreAlgorithmTotalMatches1LevelAll scores the only word
+		ret := heap.Pop(ScoreAlgorithmTotalMatches1LevelAll(guesses.Words, possibleWords, initialGuesses, depth, bestScoreSoFar)).(Item)
+		return ret.Score, []WordleWord{ret.Value}
+	}
+	key := memoKey(MemoMatches, guesses.Hash, possibleWords)
+	if score, words, ok := scoreForPossibleWords(key); ok {
+		return score, words
+	}
+	minHeap := ScoreAlgorithmTotalMatches1LevelAll(guesses.Words, possibleWords, initialGuesses, depth, bestScoreSoFar)
 	ret := heap.Pop(minHeap).(Item)
-	return ret.Score, []WordleWord{ret.Value}
+	return rememberBest(key, len(possibleWords), ret.Score, []WordleWord{ret.Value})
 }
 
-// total number of words
+// total number of words, each solution scores the size of its partition, the possible words with the same feedback
 func GuessScore(guess WordleWord, possibleWords []WordleWord, allWords []WordleWord, depth int) int {
-	game := NewWordleMatcher(possibleWords)
-	score := 0
+	code := EncodeWord(guess)
+	partitions := [FeedbackCodes]int{}
 	guessInPossibleWords := false
 	for _, solution := range possibleWords {
-		if string(solution[:]) == string(guess[:]) {
+		solutionCode := EncodeWord(solution)
+		if solutionCode == code {
 			guessInPossibleWords = true
 		}
-		matching := game.Matching2(WordleAnswer2(solution, guess))
-		score += len(matching)
+		partitions[Feedback(solutionCode, code)]++
+	}
+	score := 0
+	for _, count := range partitions {
+		score += count * count
 	}
 	if guessInPossibleWords && score >= 2 {
 		score -= 2
@@ -499,48 +512,84 @@ func ScoreAlgorithmTotalMatches1LevelAll(allWords, possibleWords, initialGuesses
 		return ret
 	}
 
-	// use possible words first - then rest of the words
-	initialGuessMap := make(map[string]bool, len(initialGuesses))
+	for _, guess := range orderGuesses(allWords, initialGuesses) {
+		score := GuessScore(guess, possibleWords, allWords, depth)
+		heap.Push(ret, Item{Value: guess, Score: score})
+	}
+	return ret
+}
+
+// orderGuesses puts the initial guesses first - then rest of the words
+func orderGuesses(allWords, initialGuesses []WordleWord) []WordleWord {
+	initialGuessMap := make(map[WordCode]bool, len(initialGuesses))
 	orderedGuesses := make([]WordleWord, len(initialGuesses))
 	copy(orderedGuesses, initialGuesses)
 
 	for _, guess := range initialGuesses {
-		initialGuessMap[string(guess[:])] = true
+		initialGuessMap[EncodeWord(guess)] = true
 	}
 	for _, guess := range allWords {
-		if _, ok := initialGuessMap[string(guess[:])]; !ok {
+		if !initialGuessMap[EncodeWord(guess)] {
 			orderedGuesses = append(orderedGuesses, guess)
 		}
 	}
-	for _, guess := range orderedGuesses {
-		score := GuessScore(guess, possibleWords, allWords, depth)
-		heap.Push(ret, Item{Value: guess, Score: score})
-	}
-	return ret
+	return orderedGuesses
 }
 
-var BestGuess1 func([]WordleWord, []WordleWord, []WordleWord, int, int) (int, []WordleWord) = ScoreAlgorithmTotalMatches1Level
+// BestGuessFunc returns the score and the best guesses from the guesses for the possibleWords, initialGuesses are tried first
+type BestGuessFunc func(guesses GuessList, possibleWords, initialGuesses []WordleWord, depth int, bestScoreSoFar int) (int, []WordleWord)
+
+var BestGuess1 BestGuessFunc = ScoreAlgorithmTotalMatches1Level
+
+// DefaultMaxGuesses is the number of guesses allowed in a game of wordle
+const DefaultMaxGuesses = 6
+
+type SimulateResult struct {
+	Answer  string   `json:"answer"`
+	Guesses []string `json:"guesses"`
+	Colors  []string `json:"colors"` // Colors[i] answer colors for Guesses[i]
+	Solved  bool     `json:"solved"` // false if the answer was not found in the allowed number of guesses
+}
 
 // Simulate a game of wordle.
 // words_s - dictionary of words
 // solution - answer
 // first_guess - first guess
+// Returns the guesses made, use SimulateGame to tell a solved game from a failed one
 func Simulate(words_s []string, solution_s string, first_guess_s string) []string {
-	words := StringsToWordleWords(words_s)
+	return SimulateGame(words_s, solution_s, first_guess_s, DefaultMaxGuesses).Guesses
+}
+
+// SimulateGame plays a game of wordle stopping after maxGuesses.  An unsolved game is returned with Solved false
+func SimulateGame(words_s []string, solution_s string, first_guess_s string, maxGuesses int) SimulateResult {
+	return SimulateWordLists(NewWordLists(words_s, nil), solution_s, first_guess_s, maxGuesses)
+}
+
+// SimulateWordLists plays a game of wordle guessing from the Guesses and narrowing down the Answers
+func SimulateWordLists(wordLists *WordLists, solution_s string, first_guess_s string, maxGuesses int) SimulateResult {
+	return SimulateStrategy(wordLists, BestGuess1, solution_s, first_guess_s, maxGuesses)
+}
+
+// SimulateStrategy is SimulateWordLists with the bestGuess strategy instead of BestGuess1
+func SimulateStrategy(wordLists *WordLists, bestGuess BestGuessFunc, solution_s string, first_guess_s string, maxGuesses int) SimulateResult {
 	solution := WordleWord([]rune(solution_s))
 	guess := WordleWord([]rune(first_guess_s))
-	guesses := []string{}
+	ret := SimulateResult{Answer: solution_s, Guesses: []string{}, Colors: []string{}}
 	gas := make([]GuessAnswer, 0)
-	for guessCount := 0; guessCount < 6; guessCount++ {
-		guesses = append(guesses, string(guess[:]))
+	for guessCount := 0; guessCount < maxGuesses; guessCount++ {
 		answer := WordleAnswer(solution, guess)
-		if string(answer[:]) == "ggggg" {
-			return guesses
+		ret.Guesses = append(ret.Guesses, string(guess[:]))
+		ret.Colors = append(ret.Colors, string(answer[:]))
+		if answer == allGreen {
+			ret.Solved = true
+			return ret
+		}
+		gas = append(gas, GuessAnswer{guess, answer})
+		if guessCount+1 < maxGuesses {
+			guess = playStrategy(bestGuess, wordLists, gas)
 		}
-		gas = append(gas, GuessAnswer{guess, WordleAnswer(solution, guess)})
-		guess = PlayWordle(words, gas)
 	}
-	panic("unexpected Simulate end")
+	return ret
 }
 
 type SolutionsAnswers struct {
@@ -583,14 +632,19 @@ func UniqueAnswerResults(wordListStrings []string, guess string) map[string][]st
 	wordList := StringsToWordleWords(wordListStrings)
 	guessWW := WordleWord([]rune(guess))
 	answerSolutions := make(map[string][]string)
-	for _, solutionWW := range wordList {
-		answer := WordleAnswer2(solutionWW, guessWW)
-		answerColors := string(answer.Colors[:])
-		if answers, ok := answerSolutions[string(answerColors)]; ok {
-			answerSolutions[answerColors] = append(answers, string(solutionWW[:]))
-		} else {
-			answerSolutions[answerColors] = []string{string(solutionWW[:])}
-		}
+	for answerColors, solutions := range AnswerPartition(wordList, guessWW) {
+		answerSolutions[string(answerColors[:])] = WordleWordsToStrings(solutions)
 	}
 	return answerSolutions
 }
+
+// AnswerPartition splits the possible words by the answer colors the guess would get, key = answer colors
+// value = solutions in the order they appear in possibleWords
+func AnswerPartition(possibleWords []WordleWord, guess WordleWord) map[WordleWord][]WordleWord {
+	ret := make(map[WordleWord][]WordleWord)
+	for _, solution := range possibleWords {
+		answer := WordleAnswer2(solution, guess)
+		ret[answer.Colors] = append(ret[answer.Colors], solution)
+	}
+	return ret
+}
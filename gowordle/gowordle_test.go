@@ -1,10 +1,18 @@
 package gowordle
 
 import (
+	"context"
+	"encoding/json"
+	"io"
 	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/bits-and-blooms/bitset"
 	"github.com/stretchr/testify/assert"
@@ -287,7 +295,7 @@ func TestPlayArise(t *testing.T) {
 		{WordleWord([]rune("arise")), WordleWord([]rune("yrrry"))},
 		{WordleWord([]rune("metal")), WordleWord([]rune("rgggg"))},
 	}
-	ww := PlayWordle(wordList, guessAnswers)
+	ww := PlayWordle(wordList, wordList, guessAnswers)
 	println(string(ww[:]))
 	print("done")
 }
@@ -433,6 +441,46 @@ func BenchmarkMapIndex(t *testing.B) {
 	println("miss:", MissCount)
 }
 
+var HitmissCode map[uint64]*Answer = make(map[uint64]*Answer, 10000)
+
+func testMapCode(solution, guess WordCode) *Answer {
+	key := uint64(solution)<<32 | uint64(guess)
+	if ret, ok := HitmissCode[key]; ok {
+		HitCount++
+		return ret
+	}
+	ret := Answer{}
+	MissCount++
+	HitmissCode[key] = &ret
+	return &ret
+}
+
+// BenchmarkMapCode is BenchmarkMapStrings with the words packed in WordCodes.  The other map benchmarks do L
+// lookups per op, per lookup they were: strings 371ns, int 57ns, index 42ns and code 59ns.
+func BenchmarkMapCode(t *testing.B) {
+	codes := EncodeWords(StringsToWordleWords(WordleDictionary))
+	choices := Choices
+	for i := 0; i < t.N; i++ {
+		testMapCode(codes[rand.Intn(choices)], codes[rand.Intn(choices)])
+	}
+	println("hits:", HitCount)
+	println("miss:", MissCount)
+}
+
+func BenchmarkWordleAnswer2(t *testing.B) {
+	allWords := StringsToWordleWords(WordleDictionary)
+	for i := 0; i < t.N; i++ {
+		WordleAnswer2(allWords[i%Choices], allWords[(i/Choices)%Choices])
+	}
+}
+
+func BenchmarkFeedback(t *testing.B) {
+	codes := EncodeWords(StringsToWordleWords(WordleDictionary))
+	for i := 0; i < t.N; i++ {
+		Feedback(codes[i%Choices], codes[(i/Choices)%Choices])
+	}
+}
+
 func BenchmarkSimulate(t *testing.B) {
 	BestGuess1 = ScoreAlgorithmRecursive
 	// wordList := SortedWordleDictionary()[0:800]
@@ -442,3 +490,862 @@ func BenchmarkSimulate(t *testing.B) {
 		println(answer, guesses)
 	}
 }
+
+func TestAbsurdleAnswer(t *testing.T) {
+	words := StringsToWordleWords([]string{"aaaaa", "abbbb", "abccc", "zzzzz"})
+	assert := assert.New(t)
+	answer, possible := AbsurdleAnswer(words, WW("abxxx"))
+	assert.Equal("ggrrr", string(answer[:]))
+	assert.Equal([]string{"abbbb", "abccc"}, WordleWordsToStrings(possible))
+
+	answer, possible = AbsurdleAnswer(StringsToWordleWords([]string{"zzzzz"}), WW("zzzzz"))
+	assert.Equal("ggggg", string(answer[:]))
+	assert.Equal([]string{"zzzzz"}, WordleWordsToStrings(possible))
+
+	// tie on size, host gives away the fewest greens
+	answer, _ = AbsurdleAnswer(StringsToWordleWords([]string{"aaaaa", "zzzzz"}), WW("aaaaa"))
+	assert.Equal("rrrrr", string(answer[:]))
+}
+
+func TestAbsurdleSolve(t *testing.T) {
+	words := StringsToWordleWords(SortedWordleDictionary()[0:100])
+	guesses, ok := AbsurdleSolve(words, words, 6, 0)
+	assert := assert.New(t)
+	assert.True(ok)
+	game := NewAbsurdleGame(words)
+	for _, guess := range guesses {
+		game.Guess(guess)
+	}
+	assert.True(game.Solved())
+	// no shorter win exists
+	_, ok = AbsurdleSolve(words, words, len(guesses)-1, 0)
+	assert.False(ok)
+}
+
+func TestMultiBoard(t *testing.T) {
+	words := StringsToWordleWords([]string{"aaaaa", "abbbb", "abccc", "zzzzz"})
+	mb := NewMultiBoard(words, 2)
+	assert := assert.New(t)
+	mb.Apply(WW("abxxx"), []WordleWord{WW("ggrrr"), WW("rrrrr")})
+	assert.Equal([]string{"abbbb", "abccc"}, WordleWordsToStrings(mb.Boards[0]))
+	assert.Equal([]string{"zzzzz"}, WordleWordsToStrings(mb.Boards[1]))
+	assert.Equal(WW("zzzzz"), NextGuessMultiBoard(words, mb))
+	mb.Apply(WW("zzzzz"), []WordleWord{WW("rrrrr"), WW("ggggg")})
+	assert.Equal([]int{0}, mb.Unsolved())
+}
+
+func TestSimulateMultiBoard(t *testing.T) {
+	wordList := SortedWordleDictionary()[0:200]
+	result := SimulateMultiBoard(wordList, []string{"abbey", "agony", "alien", "basic"}, "raise", MultiBoardMaxGuesses(4))
+	assert := assert.New(t)
+	assert.True(result.Solved)
+	for i, solution := range result.Solutions {
+		assert.Equal(solution, result.Guesses[result.SolvedAt[i]-1])
+	}
+}
+
+func TestSimulateGameFailed(t *testing.T) {
+	wordList := SortedWordleDictionary()[0:200]
+	assert := assert.New(t)
+	result := SimulateGame(wordList, "baste", "raise", 1)
+	assert.False(result.Solved)
+	assert.Equal([]string{"raise"}, result.Guesses)
+	assert.Equal(len(result.Guesses), len(result.Colors))
+
+	result = SimulateGame(wordList, "baste", "raise", DefaultMaxGuesses)
+	assert.True(result.Solved)
+	assert.Equal("ggggg", result.Colors[len(result.Colors)-1])
+}
+
+func TestParseShareGrid(t *testing.T) {
+	assert := assert.New(t)
+	grid, err := ParseShareGrid("Wordle 1,234 3/6*\n\n⬛🟨⬛⬛⬜\n🟦🟩⬛⬛⬛\n🟩🟩🟩🟩🟩\n")
+	assert.NoError(err)
+	assert.Equal(1234, grid.Puzzle)
+	assert.Equal(3, grid.Guesses)
+	assert.Equal(6, grid.MaxGuesses)
+	assert.True(grid.HardMode)
+	assert.True(grid.Solved())
+	assert.Equal([]WordleWord{WW("ryrrr"), WW("ygrrr"), WW("ggggg")}, grid.Rows)
+
+	grid, err = ParseShareGrid("Wordle 812 X/6 ⬛🟨⬛⬛⬛⬛⬛🟩🟨⬛")
+	assert.NoError(err)
+	assert.Equal(0, grid.Guesses)
+	assert.False(grid.Solved())
+	assert.Equal(2, len(grid.Rows))
+
+	_, err = ParseShareGrid("Wordle 812 2/6\n⬛🟨⬛⬛\n🟩🟩🟩🟩🟩")
+	assert.Error(err)
+	_, err = ParseShareGrid("Wordle 812 3/6\n⬛🟨⬛⬛⬛\n🟩🟩🟩🟩🟩")
+	assert.Error(err)
+}
+
+func TestReverseSolve(t *testing.T) {
+	words := StringsToWordleWords([]string{"ghost", "ghoul", "quote", "booty", "wrote"})
+	rows := ReverseSolve(words, WW("ghost"), []WordleWord{WW("rrgyr"), WW("gggrr"), WW("ggggg")})
+	assert := assert.New(t)
+	assert.Equal([]string{"quote", "booty", "wrote"}, WordleWordsToStrings(rows[0].Candidates))
+	assert.False(rows[0].Unique)
+	assert.Equal([]string{"ghoul"}, WordleWordsToStrings(rows[1].Candidates))
+	assert.True(rows[1].Unique)
+	assert.True(rows[2].Unique)
+}
+
+func TestAnalyzeGame(t *testing.T) {
+	words := StringsToWordleWords(SortedWordleDictionary()[0:200])
+	guesses := StringsToWordleWords([]string{"raise", "abbey"})
+	analysis := AnalyzeGame(NewGuessList(words), words, WW("abbey"), guesses, WW("raise"))
+	assert := assert.New(t)
+	assert.True(analysis.Solved)
+	assert.Equal(2, len(analysis.Guesses))
+	first := analysis.Guesses[0]
+	assert.Equal(200, first.Before)
+	assert.Equal(first.After, analysis.Guesses[1].Before)
+	assert.Equal(WW("raise"), first.Best)
+	assert.InDelta(0, first.Skill, 0.0001)
+	assert.Equal(100.0, first.SkillRating)
+	assert.Equal(first.BestExpected, analysis.BestExpected)
+	last := analysis.Guesses[1]
+	assert.Equal(WW("ggggg"), last.Colors)
+	assert.Equal(1, last.After)
+	// solving on the guess is all luck, every other answer would have taken more guesses
+	assert.InDelta(last.Expected-1, last.Luck, 0.0001)
+	assert.InDelta(first.Luck+last.Luck, analysis.Luck, 0.0001)
+
+	// the strategy's guess is remembered for the guesses and the possible words, not just the possible words
+	possible := words[0:3]
+	StrategyGuess(NewGuessList(possible), possible)
+	StrategyGuess(NewGuessList(words), possible)
+	reversed := []WordleWord{possible[2], possible[1], possible[0]}
+	assert.NotEqual(analyzeKey(NewGuessList(possible), possible), analyzeKey(NewGuessList(words), possible))
+	assert.Equal(analyzeKey(NewGuessList(possible), possible), analyzeKey(NewGuessList(reversed), reversed))
+	assert.Contains(strategyGuessCache, analyzeKey(NewGuessList(possible), possible))
+	assert.Contains(strategyGuessCache, analyzeKey(NewGuessList(words), possible))
+}
+
+func TestParseFeedback(t *testing.T) {
+	assert := assert.New(t)
+	for _, feedback := range []string{"gyrrr", "GYRRR", "gybbb", "gyxxx", "gy...", "gy---", "21000", "🟩🟨⬛⬛⬛", "🟩🟨⬜️⬜️⬜️"} {
+		colors, err := ParseFeedback(feedback)
+		assert.NoError(err, feedback)
+		assert.Equal(WW("gyrrr"), colors, feedback)
+	}
+	_, err := ParseFeedback("gyrrz")
+	assert.Error(err)
+	_, err = ParseFeedback("gyrr")
+	assert.Error(err)
+	// the matcher only takes parsed colors, a b is not red
+	assert.Panics(func() { MakeLetterMatch(WW("crane"), WW("gybbb")) })
+
+	fp := NewFeedbackParser()
+	assert.Error(fp.SetNotation("+?"))
+	assert.NoError(fp.SetNotation("+?b"))
+	colors, err := fp.Parse("+?bb.")
+	assert.NoError(err)
+	assert.Equal(WW("gyrrr"), colors)
+	assert.Equal("+?bbb", fp.Format(colors))
+	assert.NoError(fp.SetNotation("emoji"))
+	assert.Equal("🟩🟨⬛⬛⬛", fp.Format(colors))
+}
+
+func TestWordLists(t *testing.T) {
+	wl := NewWordLists([]string{"abbey", "zesty", "abbey"}, []string{"soare", "zesty"})
+	assert := assert.New(t)
+	assert.Equal([]string{"abbey", "zesty"}, WordleWordsToStrings(wl.Answers))
+	assert.Equal([]string{"soare", "zesty", "abbey"}, WordleWordsToStrings(wl.Guesses))
+	assert.True(wl.ValidGuess(WW("soare")))
+	assert.True(wl.ValidGuess(WW("abbey")))
+	assert.False(wl.ValidGuess(WW("zzzzz")))
+
+	wl = DefaultWordLists(200)
+	assert.Equal(200, len(wl.Answers))
+	assert.Equal(len(SortedWordleDictionary())+len(WordleExtraGuesses), len(wl.Guesses))
+	result := SimulateWordLists(wl, "abbey", "soare", DefaultMaxGuesses)
+	assert.True(result.Solved)
+}
+
+func TestLoadDictionary(t *testing.T) {
+	assert := assert.New(t)
+	dict, err := LoadDictionary(strings.NewReader("# answers\nCigar\n\nrebut\ncigar\n"), DictionaryText)
+	assert.NoError(err)
+	assert.Equal([]string{"cigar", "rebut"}, dict.Words)
+	assert.Nil(dict.Weights)
+	assert.Equal(HashWords([]string{"cigar", "rebut"}), dict.Hash)
+
+	_, err = LoadDictionary(strings.NewReader("cigar\nrebut\nsissy!\n"), DictionaryText)
+	assert.ErrorContains(err, "line 3")
+
+	dict, err = LoadDictionary(strings.NewReader("word,weight\ncigar,1.5\nrebut, 0.25\n"), DictionaryCSV)
+	assert.NoError(err)
+	assert.Equal([]string{"cigar", "rebut"}, dict.Words)
+	assert.Equal(0.25, dict.Weights["rebut"])
+	_, err = LoadDictionary(strings.NewReader("cigar,1\nrebut,x\n"), DictionaryCSV)
+	assert.ErrorContains(err, "line 2")
+
+	dict, err = LoadDictionary(strings.NewReader("[\"cigar\",\n\"REBUT\"]"), DictionaryJSON)
+	assert.NoError(err)
+	assert.Equal([]string{"cigar", "rebut"}, dict.Words)
+	dict, err = LoadDictionary(strings.NewReader("{\"cigar\": 2,\n\"rebut\": 1}"), DictionaryJSON)
+	assert.NoError(err)
+	assert.Equal(2.0, dict.Weights["cigar"])
+	_, err = LoadDictionary(strings.NewReader("[\"cigar\",\n\"rebut\",\n\"toolong\"]"), DictionaryJSON)
+	assert.ErrorContains(err, "line 3")
+
+	assert.Equal(2309, len(WordleDictionary))
+	assert.Equal("cigar", WordleDictionaryOrig[0])
+}
+
+func TestAnswerHistory(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(0, PuzzleNumber(FirstPuzzleDate))
+	puzzle, err := ParsePuzzleDate("2021-06-21")
+	assert.NoError(err)
+	assert.Equal(2, puzzle)
+	_, err = ParsePuzzleDate("2021-06-18")
+	assert.Error(err)
+
+	history := DefaultAnswerHistory()
+	assert.Equal([]string{"cigar", "rebut"}, history.UsedBefore(2))
+	assert.Equal([]string{"sissy", "abbey"}, ExcludeUsed([]string{"cigar", "sissy", "rebut", "abbey"}, history.UsedBefore(2)))
+
+	history, err = loadAnswerHistoryCSV(strings.NewReader("puzzle,answer\n5,Rebut\n3,cigar\n"))
+	assert.NoError(err)
+	assert.Equal([]string{"cigar"}, history.UsedBefore(5))
+	_, err = loadAnswerHistoryCSV(strings.NewReader("3,cigar\n4,cigars\n"))
+	assert.ErrorContains(err, "line 2")
+}
+
+func TestAuditDictionary(t *testing.T) {
+	entries, err := readDictionaryEntries(strings.NewReader("cigar\nCigar\nrhythm\nnymph\ncrwth\nsissy\nqajaq\ncafés\n"), DictionaryText)
+	assert := assert.New(t)
+	assert.NoError(err)
+	problems := []string{}
+	for _, finding := range auditEntries(entries) {
+		problems = append(problems, finding.Word+" "+finding.Problem)
+	}
+	assert.Equal([]string{
+		"Cigar upper case 'C'",
+		"Cigar duplicate of line 1",
+		"rhythm has 6 letters",
+		"crwth no vowels",
+		"sissy letter s 3 times",
+		"qajaq q not followed by u",
+		"cafés non-ASCII rune 'é'",
+	}, problems)
+}
+
+func TestDiffDictionaries(t *testing.T) {
+	a := SortedWordleDictionary()[0:60]
+	b := append(append([]string{}, a[5:]...), "zesty", "quiet")
+	guesses := StringsToWordleWords(SortedWordleDictionary()[0:100])
+	diff := DiffDictionaries(guesses, a, b)
+	assert := assert.New(t)
+	assert.Equal([]string{"zesty", "quiet"}, diff.Added)
+	assert.Equal(a[0:5], diff.Removed)
+	assert.Equal(7, len(diff.Shifts))
+
+	// the incremental scores match GuessScore
+	allGuesses := NewWordLists(append(append([]string{}, a...), b...), WordleWordsToStrings(guesses)).Guesses
+	answers := StringsToWordleWords(b)
+	assert.Equal(GuessScore(WW(diff.BestB), answers, allGuesses, 1), diff.ScoreB)
+	for _, guess := range allGuesses {
+		assert.GreaterOrEqual(GuessScore(guess, answers, allGuesses, 1), diff.ScoreB)
+	}
+}
+
+func TestSession(t *testing.T) {
+	words := NewWordLists([]string{"ghost", "ghoul", "quote", "booty", "wrote"}, []string{"soare"})
+	session := NewSession(words, WW("soare"))
+	assert := assert.New(t)
+	assert.Equal(WW("soare"), session.Suggest())
+	assert.NoError(session.Apply(WW("ghoul"), WW("gggrr")))
+	assert.Equal([]string{"ghost"}, WordleWordsToStrings(session.Possible()))
+	assert.Contains(session.Why(WW("quote")), "ruled out by guess 1")
+	assert.Contains(session.Why(WW("ghost")), "one of the 1 possible")
+	assert.Contains(session.Why(WW("soare")), "not in the answer list")
+	assert.Error(session.Apply(WW("booty"), WW("ggggg")))
+	assert.Error(session.Apply(WW("zzzxy"), WW("yyyrr")))
+	assert.Equal(1, len(session.History))
+	assert.True(session.Undo())
+	assert.Equal(5, len(session.Possible()))
+	assert.False(session.Undo())
+	assert.Equal(3, len(session.Top(3)))
+
+	// a history that contradicts itself is replayed and each guess that ruled out a word is explained
+	session.Replay(WW("ghoul"), WW("gggrr"))
+	session.Replay(WW("booty"), WW("ggggg"))
+	assert.Empty(session.Possible())
+	assert.Contains(session.Why(WW("ghost")), "ruled out by guess 2")
+	assert.Contains(session.Why(WW("quote")), "ruled out by guess 1")
+	assert.Contains(session.Why(WW("quote")), "; and by guess 2")
+	assert.NotContains(session.Why(WW("booty")), "by guess 2")
+}
+
+func TestExcluded(t *testing.T) {
+	assert := assert.New(t)
+	for _, test := range []struct{ word, guess, feedback, rule string }{
+		{"quote", "ghoul", "gggrr", RuleGreenMismatch},
+		{"crane", "eerie", "yyrrr", RuleTooFew},
+		{"crane", "bread", "rrrrr", RuleRedPresent},
+		{"emcee", "eerie", "grrrr", RuleTooMany},
+		{"crane", "cloud", "yrrrr", RuleYellowSpot},
+		{"token", "speed", "rryrr", RuleRedSpot},
+		{"ghost", "ghoul", "gggrr", ""},
+		{"ghost", "zzzxy", "yyyrr", RuleTooFew}, // no word has three z
+	} {
+		exclusion, excluded := Excluded(WW(test.word), WW(test.guess), WW(test.feedback))
+		assert.Equal(test.rule, exclusion.Rule, test.word)
+		// the rules agree with the matcher
+		matching := NewWordleMatcher([]WordleWord{WW(test.word)}).Matching(WW(test.guess), WW(test.feedback))
+		assert.Equal(excluded, len(matching) == 0, test.word)
+	}
+	exclusion, _ := Excluded(WW("emcee"), WW("eerie"), WW("grrrr"))
+	assert.Equal(Exclusion{Guess: WW("eerie"), Feedback: WW("grrrr"), Rule: RuleTooMany, Letter: 'e', Count: 1, Copies: 3}, exclusion)
+	history := []GuessAnswer{{WW("crane"), WordleAnswer(WW("token"), WW("crane"))}, {WW("speed"), WW("rryrr")}}
+	exclusion, excluded := ExcludedByHistory(WW("token"), history)
+	assert.True(excluded)
+	assert.Equal(2, exclusion.Turn)
+	assert.Equal(4, exclusion.Position)
+	history = []GuessAnswer{{WW("ghoul"), WW("gggrr")}, {WW("booty"), WW("ggggg")}}
+	exclusions := ExclusionsByHistory(WW("quote"), history)
+	assert.Equal(2, len(exclusions))
+	assert.Equal([]int{1, 2}, []int{exclusions[0].Turn, exclusions[1].Turn})
+	assert.Empty(ExclusionsByHistory(WW("ghost"), history[:1]))
+}
+
+func TestHint(t *testing.T) {
+	words := NewWordLists([]string{"ghost", "ghoul", "geese", "booty", "wrote"}, []string{"soare"})
+	session := NewSession(words, WW("soare"))
+	assert := assert.New(t)
+	_, err := session.Hint(0)
+	assert.Error(err)
+	hint, err := session.Hint(1)
+	assert.NoError(err)
+	assert.Equal(Hint{Level: 1, Remaining: 5}, hint)
+	hint, err = session.Hint(4)
+	assert.NoError(err)
+	assert.Equal([]rune{'e', 'g', 'h'}, hint.Letters)
+	assert.Equal([]int{2, 3, 2}, hint.LetterAnswers)
+	assert.Equal(2, hint.Repeated)
+	assert.Equal(WW("soare"), hint.Guess)
+	assert.True(hint.Opener)
+	assert.NoError(session.Apply(WW("ghoul"), WW("gggrr")))
+	hint, err = session.Hint(4)
+	assert.NoError(err)
+	assert.False(hint.Opener)
+	assert.Equal(1, hint.Remaining)
+	assert.Empty(hint.Letters)
+	assert.Equal(WW("ghost"), hint.Guess)
+	assert.Equal(1.0, hint.GuessAfter)
+}
+
+func TestGame(t *testing.T) {
+	words := NewWordLists([]string{"ghost", "ghoul", "quote"}, []string{"soare"})
+	game := NewGame(words, WW("ghost"), 3)
+	assert := assert.New(t)
+	_, err := game.Guess(WW("zzzzz"))
+	assert.Error(err)
+	colors, err := game.Guess(WW("soare"))
+	assert.NoError(err)
+	assert.Equal(WW("yyrrr"), colors)
+	_, err = game.Guess(WW("ghoul"))
+	assert.NoError(err)
+	assert.Equal(map[rune]rune{'s': 'y', 'o': 'g', 'a': 'r', 'r': 'r', 'e': 'r', 'g': 'g', 'h': 'g', 'u': 'r', 'l': 'r'}, game.Keyboard())
+	assert.False(game.Over())
+	_, err = game.Guess(WW("ghost"))
+	assert.NoError(err)
+	assert.True(game.Solved())
+	_, err = game.Guess(WW("ghost"))
+	assert.Error(err)
+
+	grid, err := ParseShareGrid(game.ShareGrid(812, false))
+	assert.NoError(err)
+	assert.Equal(812, grid.Puzzle)
+	assert.Equal(3, grid.Guesses)
+	assert.Equal([]WordleWord{WW("yyrrr"), WW("gggrr"), WW("ggggg")}, grid.Rows)
+}
+
+func TestBenchmark(t *testing.T) {
+	words := NewWordLists(SortedWordleDictionary()[:60], nil)
+	answers := WordleWordsToStrings(words.Answers)
+	assert := assert.New(t)
+	games := 0
+	serial := RunBenchmark(words, Strategies[0], answers, "raise", DefaultMaxGuesses, 1, func(BenchmarkGame) { games++ })
+	assert.Equal(len(answers), games)
+	parallel := RunBenchmark(words, Strategies[0], answers, "raise", DefaultMaxGuesses, 4, nil)
+	assert.Empty(Disagreements([]BenchmarkResult{serial, parallel}))
+	for i, game := range parallel.Games {
+		assert.Equal(answers[i], game.Answer)
+		assert.Equal(SimulateWordLists(words, answers[i], "raise", DefaultMaxGuesses), game.SimulateResult)
+	}
+	average, interval := serial.Average()
+	assert.Greater(average, 1.0)
+	assert.Greater(interval, 0.0)
+	total := 0
+	for _, count := range serial.Distribution() {
+		total += count
+	}
+	assert.Equal(len(answers)-len(serial.Failed()), total)
+	worst := serial.Worst(3)
+	assert.Len(worst, 3)
+	assert.GreaterOrEqual(len(worst[0].Guesses), len(worst[2].Guesses))
+
+	answersOnly, err := FindStrategy("answers")
+	assert.NoError(err)
+	for _, game := range RunBenchmark(words, answersOnly, answers, "raise", DefaultMaxGuesses, 2, nil).Games {
+		for _, guess := range game.Guesses[1:] {
+			assert.Contains(answers, guess)
+		}
+	}
+	_, err = FindStrategy("nope")
+	assert.Error(err)
+}
+
+func TestMemo(t *testing.T) {
+	assert := assert.New(t)
+	words := StringsToWordleWords([]string{"cigar", "rebut", "sissy"})
+	reversed := StringsToWordleWords([]string{"sissy", "rebut", "cigar"})
+	assert.Equal(NewWordleMatcher(words).ContentHash(), NewWordleMatcher(reversed).ContentHash())
+	assert.Equal(NewGuessList(words), GuessList{words, NewWordleMatcher(reversed).ContentHash()})
+	assert.Equal(memoKey(MemoMatches, NewGuessList(words).Hash, words), memoKey(MemoMatches, NewGuessList(reversed).Hash, reversed))
+	lists := NewWordLists([]string{"cigar", "rebut"}, []string{"sissy"})
+	assert.Equal(NewGuessList(words).Hash, lists.GuessList().Hash)
+	assert.Equal(MemoMatches+":"+NewWordleMatcher(lists.Guesses).ContentHash()+":"+NewWordleMatcher(words).ContentHash(),
+		memoKey(MemoMatches, lists.GuessList().Hash, words)) // the same keys as memo files written before
+
+	path := filepath.Join(t.TempDir(), "memo.jsonl")
+	memo, err := LoadMemoFile(path)
+	assert.NoError(err)
+	assert.NoError(memo.Put(memoEntry("matches:a:b", 3, 7, words[:1])))
+	assert.NoError(memo.Put(memoEntry("matches:a:b", 3, 9, words[1:2]))) // the first entry is kept
+	assert.NoError(memo.Put(memoEntry("recursive:a:c", 20, 250, words[1:])))
+	assert.NoError(memo.Close())
+	// a run killed while writing leaves a partial line
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	assert.NoError(err)
+	file.WriteString(`{"key":"matches:a:d","si`)
+	file.Close()
+
+	memo, err = LoadMemoFile(path)
+	assert.NoError(err)
+	assert.Equal(2, memo.Loaded)
+	entry, ok := memo.Get("matches:a:b")
+	assert.True(ok)
+	assert.Equal([]string{"cigar"}, entry.Words)
+	assert.Equal([]int{7}, entry.Scores)
+	assert.Equal("recursive", memo.Entries()[1].Kind())
+	assert.Equal("a", memo.Entries()[1].GuessesHash())
+	assert.NoError(memo.Put(memoEntry("ranking:a:e", 2, 1, words[:2])))
+	removed, err := memo.Prune(func(entry MemoEntry) bool { return entry.Size > 2 })
+	assert.NoError(err)
+	assert.Equal(1, removed)
+	assert.NoError(memo.Close())
+
+	memo, err = LoadMemoFile(path)
+	assert.NoError(err)
+	assert.Equal(2, memo.Loaded)
+	memo.Close()
+	os.WriteFile(path, []byte("not json\n{}\n"), 0644)
+	_, err = LoadMemoFile(path)
+	assert.Error(err)
+	for _, bad := range []string{`{"key":"matches:a:b","size":2,"words":[],"scores":[]}`, `{"key":"matches:a:b","size":2,"words":["cig"],"scores":[1]}`} {
+		os.WriteFile(path, []byte(bad+"\n"), 0644)
+		_, err = LoadMemoFile(path)
+		assert.Error(err, bad)
+	}
+}
+
+func TestCheckpoint(t *testing.T) {
+	assert := assert.New(t)
+	words := NewWordLists(SortedWordleDictionary()[:40], nil)
+	header := CheckpointHeader{Words: words.Hash, FirstGuess: "raise", MaxGuesses: DefaultMaxGuesses, Strategy: "matches"}
+	path := filepath.Join(t.TempDir(), "sim.jsonl")
+	checkpoint, err := OpenCheckpoint(path, header)
+	assert.NoError(err)
+	assert.Empty(checkpoint.Games)
+	first := SimulateWordLists(words, "aback", "raise", DefaultMaxGuesses)
+	assert.NoError(checkpoint.Add(first))
+	assert.NoError(checkpoint.Add(SimulateWordLists(words, "abase", "raise", DefaultMaxGuesses)))
+	assert.NoError(checkpoint.Close())
+	// stopped while writing a game
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	assert.NoError(err)
+	file.WriteString(`{"answer":"abate","gue`)
+	file.Close()
+
+	checkpoint, err = OpenCheckpoint(path, header)
+	assert.NoError(err)
+	assert.Len(checkpoint.Games, 2)
+	assert.Equal(first, checkpoint.Games["aback"])
+	assert.NoError(checkpoint.Add(SimulateWordLists(words, "abate", "raise", DefaultMaxGuesses)))
+	assert.NoError(checkpoint.Close())
+	checkpoint, err = OpenCheckpoint(path, header)
+	assert.NoError(err)
+	assert.Len(checkpoint.Games, 3)
+	checkpoint.Close()
+
+	header.FirstGuess = "arise"
+	_, err = OpenCheckpoint(path, header)
+	assert.Error(err)
+
+	// the header is the first line that is not empty, a file of empty lines gets a header
+	os.WriteFile(path, []byte("\n\n"), 0644)
+	checkpoint, err = OpenCheckpoint(path, header)
+	assert.NoError(err)
+	assert.NoError(checkpoint.Add(first))
+	checkpoint.Close()
+	checkpoint, err = OpenCheckpoint(path, header)
+	assert.NoError(err)
+	assert.Len(checkpoint.Games, 1)
+	checkpoint.Close()
+}
+
+// post the JSON request to the test server and decode the JSON response
+func post(t *testing.T, server *httptest.Server, path string, request string, response any) int {
+	resp, err := http.Post(server.URL+path, "application/json", strings.NewReader(request))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode
+}
+
+func TestAPI(t *testing.T) {
+	words := NewWordLists([]string{"ghost", "ghoul", "quote", "booty", "wrote"}, []string{"soare"})
+	server := httptest.NewServer(NewAPI(words, WW("soare")).Handler())
+	defer server.Close()
+	assert := assert.New(t)
+	history := `"history": [{"guess": "ghost", "feedback": "rrgry"}]`
+
+	filter := APIFilterResponse{}
+	assert.Equal(http.StatusOK, post(t, server, "/filter", "{"+history+"}", &filter))
+	assert.Equal(APIFilterResponse{3, []string{"quote", "booty", "wrote"}}, filter)
+
+	suggest := APISuggestResponse{}
+	assert.Equal(http.StatusOK, post(t, server, "/suggest", `{`+history+`, "k": 2}`, &suggest))
+	assert.Equal("matches", suggest.Strategy)
+	assert.Len(suggest.Suggestions, 2)
+	assert.True(suggest.Suggestions[0].Best)
+	assert.Equal(GuessScore(WW(suggest.Suggestions[1].Guess), StringsToWordleWords(filter.Candidates), words.Guesses, 1), suggest.Suggestions[1].Score)
+	assert.Equal(http.StatusOK, post(t, server, "/suggest", `{`+history+`, "strategy": "answers", "k": 5}`, &suggest))
+	assert.Len(suggest.Suggestions, 3) // only the candidates
+	for _, suggestion := range suggest.Suggestions {
+		assert.Contains(filter.Candidates, suggestion.Guess)
+	}
+
+	partition := APIPartitionResponse{}
+	assert.Equal(http.StatusOK, post(t, server, "/partition", `{`+history+`, "guess": "wrote"}`, &partition))
+	assert.Equal(3, partition.Count)
+	assert.Equal([]APIPartitionGroup{{"ggggg", 1, []string{"wrote"}}, {"rrggg", 1, []string{"quote"}}, {"rrggr", 1, []string{"booty"}}}, partition.Partition)
+
+	feedback := APIFeedbackResponse{}
+	assert.Equal(http.StatusOK, post(t, server, "/feedback", `{"solution": "ghost", "guess": "ghoul"}`, &feedback))
+	assert.Equal("gggrr", feedback.Feedback)
+
+	game := SimulateResult{}
+	assert.Equal(http.StatusOK, post(t, server, "/simulate", `{"answer": "quote", "max_guesses": 4}`, &game))
+	assert.True(game.Solved)
+	assert.Equal("soare", game.Guesses[0])
+
+	// validation
+	for path, request := range map[string]string{
+		"/filter":    `{"history": [{"guess": "zzzzz", "feedback": "rrrrr"}]}`,
+		"/suggest":   `{"k": 1000}`,
+		"/partition": `{"guess": "ghost", "history": [{"guess": "ghost", "feedback": "yyyyy"}]}`,
+		"/feedback":  `{"solution": "ghost", "guess": "gho"}`,
+		"/simulate":  `{"answer": "soare"}`,
+	} {
+		apiError := APIError{}
+		assert.Equal(http.StatusBadRequest, post(t, server, path, request, &apiError), path)
+		assert.NotEmpty(apiError.Error, path)
+	}
+	apiError := APIError{}
+	assert.Equal(http.StatusBadRequest, post(t, server, "/suggest", `{"strategy": "recursive"}`, &apiError))
+	assert.Contains(apiError.Error, "can not suggest")
+	assert.Equal(http.StatusBadRequest, post(t, server, "/filter", `{"histroy": []}`, &apiError))
+	assert.Contains(apiError.Error, "unknown field")
+	resp, err := http.Get(server.URL + "/filter")
+	assert.NoError(err)
+	resp.Body.Close()
+	assert.Equal(http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestGameHost(t *testing.T) {
+	words := NewWordLists([]string{"ghost", "ghoul", "quote", "booty", "wrote"}, []string{"soare"})
+	history := &AnswerHistory{Answers: map[int]string{0: "ghoul", 1: "quote"}}
+	path := filepath.Join(t.TempDir(), "games.jsonl")
+	gameHost := NewGameHost(words, history, time.Hour)
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	gameHost.Now = func() time.Time { return now }
+	assert := assert.New(t)
+	assert.NoError(gameHost.Open(path))
+	server := httptest.NewServer(gameHost.Handler())
+	defer server.Close()
+
+	state := HostGameState{}
+	assert.Equal(http.StatusOK, post(t, server, "/games", `{"puzzle": 1, "hard_mode": true, "max_guesses": 3}`, &state))
+	one := 1
+	assert.Equal(HostGameState{ID: state.ID, Puzzle: &one, HardMode: true, MaxGuesses: 3, Guesses: []APIGuessFeedback{}, Expires: now.Add(time.Hour)}, state)
+	id := state.ID
+	assert.Equal(http.StatusOK, post(t, server, "/games/"+id+"/guesses", `{"guess": "wrote"}`, &state))
+	assert.Equal([]APIGuessFeedback{{"wrote", "rrggg"}}, state.Guesses)
+	apiError := APIError{}
+	assert.Equal(http.StatusBadRequest, post(t, server, "/games/"+id+"/guesses", `{"guess": "ghost"}`, &apiError))
+	assert.Contains(apiError.Error, "hard mode")
+	assert.Equal(http.StatusBadRequest, post(t, server, "/games/"+id+"/guesses", `{"guess": "zzzzz"}`, &apiError))
+	assert.Equal(http.StatusNotFound, post(t, server, "/games/nope/guesses", `{"guess": "ghost"}`, &apiError))
+	assert.Equal(http.StatusBadRequest, post(t, server, "/games", `{"seed": 1, "daily": true}`, &apiError))
+
+	other := HostGameState{}
+	assert.Equal(http.StatusOK, post(t, server, "/games", `{"seed": 7}`, &other))
+	assert.NotEqual(id, other.ID)
+	assert.Empty(other.Answer)
+	assert.Nil(other.Puzzle)
+	first := HostGameState{}
+	assert.Equal(http.StatusOK, post(t, server, "/games", `{"puzzle": 0}`, &first))
+	assert.Equal(0, *first.Puzzle)
+	assert.Equal(http.StatusOK, post(t, server, "/games/"+first.ID+"/guesses", `{"guess": "ghoul"}`, &first))
+	assert.Equal("ghoul", first.Answer)
+
+	// the games survive a restart and the old one times out
+	now = now.Add(50 * time.Minute)
+	assert.Equal(http.StatusOK, post(t, server, "/games/"+id+"/guesses", `{"guess": "quote"}`, &state))
+	assert.True(state.Solved)
+	assert.Equal("quote", state.Answer)
+	assert.NoError(gameHost.Close())
+	restarted := NewGameHost(words, history, time.Hour)
+	restarted.Now = func() time.Time { return now.Add(20 * time.Minute) }
+	assert.NoError(restarted.Open(path))
+	assert.Equal(1, restarted.Len())
+	state, err := restarted.State(id)
+	assert.NoError(err)
+	assert.Equal(2, len(state.Guesses))
+	assert.Equal(1, *state.Puzzle)
+	_, err = restarted.State(other.ID)
+	assert.Error(err)
+
+	// expired games are removed from the state file
+	restarted.Now = func() time.Time { return now.Add(2 * time.Hour) }
+	removed, err := restarted.Expire()
+	assert.NoError(err)
+	assert.Equal(1, removed)
+	data, err := os.ReadFile(path)
+	assert.NoError(err)
+	assert.NotContains(string(data), id)
+	_, err = restarted.NewGame(HostNewGameRequest{})
+	assert.NoError(err) // the state file is still open to append to
+	restarted.Close()
+	data, _ = os.ReadFile(path)
+	assert.Equal(1, strings.Count(string(data), "\n"))
+}
+
+func TestEngine(t *testing.T) {
+	words := NewWordLists([]string{"ghost", "ghoul", "quote", "booty", "wrote"}, []string{"soare"})
+	engine := NewEngine(DefaultWordLists(0), WW("soare"), Strategies[0])
+	in := strings.Join([]string{"wdl", "isready", "dict answers ghost ghoul quote booty wrote", "dict guesses soare",
+		"newgame", "go", "guess ghost rrgry", "go 100", "guess ghost", "bogus", "quit", "go"}, "\n")
+	out := strings.Builder{}
+	assert := assert.New(t)
+	assert.NoError(engine.Run(strings.NewReader(in), &out))
+	move := nextGuess(Strategies[0].BestGuess, words.GuessList(), StringsToWordleWords([]string{"quote", "booty", "wrote"}))
+	assert.Equal([]string{"id name wdl matches", "wdlok", "readyok", "bestmove soare", "bestmove " + string(move[:]),
+		"error guess <word> <feedback>", "error unknown command bogus"}, strings.Split(strings.TrimSpace(out.String()), "\n"))
+
+	// a strategy that is too slow for the move time plays the first possible answer
+	slow := Strategy{Name: "slow", BestGuess: func(guesses GuessList, possibleWords, initialGuesses []WordleWord, depth int, bestScoreSoFar int) (int, []WordleWord) {
+		time.Sleep(time.Second)
+		return 0, []WordleWord{possibleWords[len(possibleWords)-1]}
+	}}
+	engine = NewEngine(words, WW("soare"), slow)
+	in = strings.Join([]string{"guess ghost rrgry", "go 10", "go soon", "dict answers ab cd", "go"}, "\n")
+	out.Reset()
+	assert.NoError(engine.Run(strings.NewReader(in), &out))
+	assert.Equal([]string{"bestmove quote", "error go [milliseconds]", `error guess "ab" must be 5 letters`, "bestmove wrote"},
+		strings.Split(strings.TrimSpace(out.String()), "\n")) // the bad dict leaves the game alone
+}
+
+// TestEngineProcess is the engine process for TestArena
+func TestEngineProcess(t *testing.T) {
+	if os.Getenv("GOWORDLE_ENGINE_PROCESS") == "" {
+		t.Skip("run by TestArena")
+	}
+	NewEngine(DefaultWordLists(0), WW("raise"), Strategies[0]).Run(os.Stdin, os.Stdout)
+	os.Exit(0)
+}
+
+func TestArena(t *testing.T) {
+	t.Setenv("GOWORDLE_ENGINE_PROCESS", "1")
+	words := DefaultWordLists(100)
+	answers := WordleWordsToStrings(words.Answers[:10])
+	command := []string{os.Args[0], "-test.run=^TestEngineProcess$"}
+	result, err := RunArena(command, words, answers, DefaultMaxGuesses, time.Second, 2, nil)
+	assert := assert.New(t)
+	assert.NoError(err)
+	assert.Equal("wdl matches", result.Strategy)
+	// the engine plays the same games as the strategy
+	for i, game := range result.Games {
+		assert.Equal(SimulateStrategy(words, Strategies[0].BestGuess, answers[i], "raise", DefaultMaxGuesses), game.SimulateResult)
+	}
+	_, err = RunArena([]string{"false"}, words, answers, DefaultMaxGuesses, 0, 1, nil)
+	assert.Error(err)
+}
+
+func TestProgress(t *testing.T) {
+	assert := assert.New(t)
+	events := []ProgressEvent{}
+	words := DefaultWordLists(100)
+	answers := WordleWordsToStrings(words.Answers[:10])
+	progress := NewProgress("sim", len(answers), func(event ProgressEvent) { events = append(events, event) })
+	progress.Interval = 0
+	ctx, cancel := context.WithCancel(context.Background())
+	played := 0
+	result, err := RunBenchmarkContext(ctx, words, Strategies[0], answers, "raise", DefaultMaxGuesses, 1, func(game BenchmarkGame) {
+		progress.Game(game.SimulateResult)
+		if played++; played == 3 {
+			cancel()
+		}
+	})
+	progress.Finish(ProgressCancelled)
+	assert.ErrorIs(err, context.Canceled)
+	assert.Less(played, len(answers))
+	assert.Equal("", result.Games[len(answers)-1].Answer)
+	assert.Len(events, played+1)
+	last := events[len(events)-1]
+	assert.Equal(ProgressCancelled, last.State)
+	assert.Equal(played, last.Done)
+	assert.Equal(len(answers), last.Total)
+	assert.Greater(last.Average, 1.0)
+	assert.Greater(events[0].ETA, 0.0)
+
+	// a client connecting after the job is done gets the last event and the stream ends
+	cancelled := false
+	server := NewProgressServer(func() { cancelled = true })
+	httpServer := httptest.NewServer(server.Handler())
+	defer httpServer.Close()
+	server.Send(events[0])
+	server.Send(last)
+	response, err := http.Get(httpServer.URL + "/events")
+	assert.NoError(err)
+	assert.Equal("text/event-stream", response.Header.Get("Content-Type"))
+	body, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	assert.NoError(err)
+	data, _ := json.Marshal(last)
+	assert.Equal("event: progress\ndata: "+string(data)+"\n\n", string(body))
+	response, err = http.Post(httpServer.URL+"/cancel", "", nil)
+	assert.NoError(err)
+	response.Body.Close()
+	assert.Equal(http.StatusAccepted, response.StatusCode)
+	assert.True(cancelled)
+
+	// a client that is behind misses running events but not the last one
+	client := make(chan ProgressEvent, 2)
+	server.clients[client] = true
+	for range 3 {
+		server.Send(events[0])
+	}
+	server.Send(last)
+	assert.Equal(events[0], <-client)
+	assert.Equal(last, <-client)
+}
+
+func TestStats(t *testing.T) {
+	assert := assert.New(t)
+	won, err := NewStatsGame("2026-10-16", "crane", []string{"raise", "trace", "crane"})
+	assert.NoError(err)
+	assert.Equal([]string{"yyrrg", "rggyg", "ggggg"}, won.Colors)
+	assert.True(won.Solved)
+	_, err = NewStatsGame("2026-10-16", "crane", []string{"crane", "trace"})
+	assert.Error(err)
+	lost, err := NewStatsGame("2026-10-17", "whole", []string{"raise", "pouty"})
+	assert.NoError(err)
+	assert.False(lost.Solved)
+	day := func(date string, game StatsGame) StatsGame {
+		game.Date = date
+		return game
+	}
+
+	// a loss and a missed day end a streak
+	games := []StatsGame{day("2026-10-10", won), day("2026-10-11", won), day("2026-10-12", won), day("2026-10-13", lost),
+		day("2026-10-14", won), day("2026-10-16", won), day("2026-10-17", won)}
+	summary := Summarize(games, "2026-10-18")
+	assert.Equal(7, summary.Played)
+	assert.Equal(6, summary.Won)
+	assert.InDelta(85.7, summary.WinPercent, 0.1)
+	assert.Equal(3, summary.MaxStreak)
+	assert.Equal(2, summary.CurrentStreak)
+	assert.Equal([]int{0, 0, 6, 0, 0, 0}, summary.Distribution)
+	assert.Equal(0, Summarize(games, "2026-10-19").CurrentStreak)
+
+	path := filepath.Join(t.TempDir(), "stats.jsonl")
+	store, err := OpenStats(path)
+	assert.NoError(err)
+	added, err := store.Import(games)
+	assert.NoError(err)
+	assert.Equal(7, added)
+	added, err = store.Import(games)
+	assert.NoError(err)
+	assert.Equal(0, added)
+	assert.NoError(store.Close())
+	store, err = OpenStats(path)
+	assert.NoError(err)
+	assert.Equal(games, store.Games)
+	assert.NoError(store.Close())
+}
+
+func TestEncoding(t *testing.T) {
+	assert := assert.New(t)
+	words := SortedWordleDictionary()
+	codes := EncodeWords(StringsToWordleWords(words))
+	for i, code := range codes {
+		assert.Equal(words[i], code.String())
+		if i > 0 {
+			assert.Less(codes[i-1], code) // codes sort like the words
+		}
+	}
+	assert.Equal(WordCode(0), EncodeWord(WW("aaaaa")))
+	assert.Equal(WordCode(25*(1+1<<5+1<<10+1<<15+1<<20)), EncodeWord(WW("zzzzz")))
+	assert.Equal(2, EncodeWord(WW("crazy")).Letter(0))
+	assert.Equal(24, EncodeWord(WW("crazy")).Letter(4))
+
+	for code := FeedbackCode(0); code < FeedbackCodes; code++ {
+		assert.Equal(code, EncodeFeedback(code.Colors()))
+	}
+	assert.Equal("rrrrr", FeedbackCode(0).String())
+	assert.Equal("ggggg", AllGreenCode.String())
+	assert.Equal(FeedbackCode(2*81+1*27), EncodeFeedback(WW("gyrrr")))
+
+	// repeated letters, only as many yellows as the solution has letters that are not green
+	for _, test := range []struct{ solution, guess, colors string }{
+		{"crane", "react", "yygyr"},
+		{"abbey", "bobby", "yrgrg"},
+		{"speed", "geese", "rygyr"},
+		{"llama", "hello", "rryyr"},
+		{"crane", "crane", "ggggg"},
+	} {
+		feedback := Feedback(EncodeWord(WW(test.solution)), EncodeWord(WW(test.guess)))
+		assert.Equal(test.colors, feedback.String(), test.solution+" "+test.guess)
+		colors := WordleAnswer(WW(test.solution), WW(test.guess))
+		assert.Equal(test.colors, string(colors[:]))
+	}
+
+	// the matcher keeps exactly the words with the same feedback
+	answers := StringsToWordleWords(words[0:300])
+	matcher := NewWordleMatcher(answers)
+	for _, guess := range []string{"zzzxy", "eerie", "speed", "abbey", "raise"} {
+		guessCode := EncodeWord(WW(guess))
+		partitions := map[FeedbackCode][]WordleWord{}
+		for _, answer := range answers {
+			feedback := Feedback(EncodeWord(answer), guessCode)
+			partitions[feedback] = append(partitions[feedback], answer)
+		}
+		for feedback, expected := range partitions {
+			assert.Equal(expected, matcher.Matching(WW(guess), feedback.Colors()), guess+" "+feedback.String())
+		}
+	}
+	// more copies of a letter than any word has
+	assert.Empty(matcher.Matching(WW("zzzxy"), WW("yyyrr")))
+}
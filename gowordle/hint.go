@@ -0,0 +1,144 @@
+package gowordle
+
+import (
+	"fmt"
+	"sort"
+)
+
+/*
+Hints give some help without giving away the best guess.  Each level adds to the levels below it and all of them
+are computed from the possible answers:
+
+	1 the number of possible answers
+	2 the letters and the spot that best split the possible answers
+	3 how many of the possible answers have a repeated letter
+	4 the best guess, before any guesses it is the configured first guess, scoring every guess against every
+	  answer is slow
+*/
+
+const MaxHintLevel = 4
+
+type Hint struct {
+	Level     int
+	Remaining int // possible answers
+	// level 2
+	Letters       []rune // letters that split the possible answers closest to in half, best first
+	LetterAnswers []int  // LetterAnswers[i] possible answers with Letters[i]
+	Spot          int    // 1 based spot where the possible answers have the most even spread of letters
+	SpotLetters   int    // different letters in the spot
+	// level 3
+	Repeated int // possible answers with a repeated letter
+	// level 4
+	Guess      WordleWord
+	GuessAfter float64 // average possible answers left after the guess
+	Opener     bool    // the Guess is the configured first guess, not computed from the possible answers
+}
+
+// hintLetters is the number of letters in a level 2 hint
+const hintLetters = 3
+
+// splitScore is the average number of possible answers left when the answers are split into groups of these sizes,
+// lower is more informative
+func splitScore(sizes []int, possible int) float64 {
+	sum := 0
+	for _, size := range sizes {
+		sum += size * size
+	}
+	return float64(sum) / float64(possible)
+}
+
+// NewHint computes the hint at the level for the possible answers, bestGuess is the level 4 guess
+func NewHint(possible []WordleWord, level int, bestGuess func() WordleWord) (Hint, error) {
+	if level < 1 || level > MaxHintLevel {
+		return Hint{}, fmt.Errorf("hint level %d is not 1 to %d", level, MaxHintLevel)
+	}
+	if len(possible) == 0 {
+		return Hint{}, fmt.Errorf("no possible answers")
+	}
+	ret := Hint{Level: level, Remaining: len(possible)}
+	if level >= 2 {
+		ret.Letters, ret.LetterAnswers = informativeLetters(possible)
+		ret.Spot, ret.SpotLetters = informativeSpot(possible)
+	}
+	if level >= 3 {
+		for _, word := range possible {
+			if hasRepeatedLetter(word) {
+				ret.Repeated++
+			}
+		}
+	}
+	if level >= 4 {
+		ret.Guess = bestGuess()
+		ret.GuessAfter = averageRemaining(AnswerPartition(possible, ret.Guess), len(possible))
+	}
+	return ret, nil
+}
+
+// informativeLetters are the letters that split the possible answers into the answers with and without the letter
+// closest to in half, letters in every possible answer or in none are left out
+func informativeLetters(possible []WordleWord) ([]rune, []int) {
+	with := [26]int{}
+	for _, word := range possible {
+		seen := [26]bool{}
+		for _, letter := range word {
+			if !seen[letter-'a'] {
+				seen[letter-'a'] = true
+				with[letter-'a']++
+			}
+		}
+	}
+	letters := []rune{}
+	for i, count := range with {
+		if count > 0 && count < len(possible) {
+			letters = append(letters, rune('a'+i))
+		}
+	}
+	score := func(letter rune) float64 {
+		count := with[letter-'a']
+		return splitScore([]int{count, len(possible) - count}, len(possible))
+	}
+	sort.SliceStable(letters, func(i, j int) bool { return score(letters[i]) < score(letters[j]) })
+	letters = letters[:min(hintLetters, len(letters))]
+	counts := make([]int, len(letters))
+	for i, letter := range letters {
+		counts[i] = with[letter-'a']
+	}
+	return letters, counts
+}
+
+// informativeSpot is the spot that splits the possible answers by the letter in the spot into the smallest groups
+func informativeSpot(possible []WordleWord) (int, int) {
+	bestSpot, bestLetters, bestScore := 0, 0, 0.0
+	for spot := 0; spot < 5; spot++ {
+		groups := map[rune]int{}
+		for _, word := range possible {
+			groups[word[spot]]++
+		}
+		sizes := make([]int, 0, len(groups))
+		for _, size := range groups {
+			sizes = append(sizes, size)
+		}
+		if score := splitScore(sizes, len(possible)); spot == 0 || score < bestScore {
+			bestSpot, bestLetters, bestScore = spot+1, len(groups), score
+		}
+	}
+	return bestSpot, bestLetters
+}
+
+func hasRepeatedLetter(word WordleWord) bool {
+	seen := [26]bool{}
+	for _, letter := range word {
+		if seen[letter-'a'] {
+			return true
+		}
+		seen[letter-'a'] = true
+	}
+	return false
+}
+
+// Hint is the hint at the level for the possible answers, the level 4 guess is Suggest
+func (s *Session) Hint(level int) (Hint, error) {
+	ret, err := NewHint(s.Possible(), level, s.Suggest)
+	ret.Opener = err == nil && level >= 4 && len(s.History) == 0
+	return ret, err
+}
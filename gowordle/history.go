@@ -0,0 +1,160 @@
+package gowordle
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+Answer history is the answer for each puzzle number.  Players know the answers that have already been used
+so the possible answers for a puzzle are the answers that were not used by an earlier puzzle.
+
+	text: one answer per line in puzzle order, the first line is puzzle 0, lines starting with # are skipped
+	csv:  puzzle,answer per line, a "puzzle,answer" header is skipped
+*/
+
+// FirstPuzzleDate is the date of puzzle 0
+var FirstPuzzleDate = time.Date(2021, time.June, 19, 0, 0, 0, 0, time.UTC)
+
+// PuzzleNumber is the puzzle played on the date
+func PuzzleNumber(date time.Time) int {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return int(day.Sub(FirstPuzzleDate).Hours() / 24)
+}
+
+// ParsePuzzleDate returns the puzzle number for a date, YYYY-MM-DD
+func ParsePuzzleDate(date string) (int, error) {
+	day, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return 0, fmt.Errorf("date %q must be YYYY-MM-DD", date)
+	}
+	ret := PuzzleNumber(day)
+	if ret < 0 {
+		return 0, fmt.Errorf("date %s is before the first puzzle %s", date, FirstPuzzleDate.Format(time.DateOnly))
+	}
+	return ret, nil
+}
+
+type AnswerHistory struct {
+	Answers map[int]string // puzzle number to answer
+}
+
+// DefaultAnswerHistory is WordleDictionaryOrig, the original order of the answers
+func DefaultAnswerHistory() *AnswerHistory {
+	ret := &AnswerHistory{Answers: make(map[int]string, len(WordleDictionaryOrig))}
+	for puzzle, answer := range WordleDictionaryOrig {
+		ret.Answers[puzzle] = answer
+	}
+	return ret
+}
+
+// LoadAnswerHistoryFile reads a history file, .csv is puzzle,answer everything else is text
+func LoadAnswerHistoryFile(path string) (*AnswerHistory, error) {
+	in, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+	var ret *AnswerHistory
+	if DictionaryFormat(path) == DictionaryCSV {
+		ret, err = loadAnswerHistoryCSV(in)
+	} else {
+		ret, err = loadAnswerHistoryText(in)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return ret, nil
+}
+
+func loadAnswerHistoryText(in io.Reader) (*AnswerHistory, error) {
+	text, err := io.ReadAll(in)
+	if err != nil {
+		return nil, err
+	}
+	ret := &AnswerHistory{Answers: make(map[int]string)}
+	for i, line := range strings.Split(string(text), "\n") {
+		line = strings.ToLower(strings.TrimSpace(line))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if _, err := ParseGuess(line); err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		ret.Answers[len(ret.Answers)] = line
+	}
+	return ret, nil
+}
+
+func loadAnswerHistoryCSV(in io.Reader) (*AnswerHistory, error) {
+	reader := csv.NewReader(in)
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+	ret := &AnswerHistory{Answers: make(map[int]string)}
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		if len(record) != 2 {
+			return nil, fmt.Errorf("line %d: expected puzzle,answer but found %d fields", line, len(record))
+		}
+		puzzle, err := strconv.Atoi(strings.TrimSpace(record[0]))
+		if err != nil {
+			if first {
+				continue // header
+			}
+			return nil, fmt.Errorf("line %d: puzzle %q is not a number", line, record[0])
+		}
+		answer := strings.ToLower(strings.TrimSpace(record[1]))
+		if _, err := ParseGuess(answer); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if previous, ok := ret.Answers[puzzle]; ok && previous != answer {
+			return nil, fmt.Errorf("line %d: puzzle %d is both %s and %s", line, puzzle, previous, answer)
+		}
+		ret.Answers[puzzle] = answer
+	}
+	return ret, nil
+}
+
+// UsedBefore are the answers of the puzzles before puzzle, in puzzle order
+func (ah *AnswerHistory) UsedBefore(puzzle int) []string {
+	puzzles := make([]int, 0, len(ah.Answers))
+	for p := range ah.Answers {
+		if p < puzzle {
+			puzzles = append(puzzles, p)
+		}
+	}
+	sort.Ints(puzzles)
+	ret := make([]string, 0, len(puzzles))
+	for _, p := range puzzles {
+		ret = append(ret, ah.Answers[p])
+	}
+	return ret
+}
+
+// ExcludeUsed returns the answers that are not in used, keeping the order
+func ExcludeUsed(answers []string, used []string) []string {
+	usedSet := make(map[string]bool, len(used))
+	for _, word := range used {
+		usedSet[word] = true
+	}
+	ret := make([]string, 0, len(answers))
+	for _, answer := range answers {
+		if !usedSet[answer] {
+			ret = append(ret, answer)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,427 @@
+package gowordle
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	mathrand "math/rand"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+GameHost hosts many games of wordle over HTTP at once, each game is a Game with its own id:
+
+	POST /games              {"seed": 42, "hard_mode": true, "max_guesses": 6}
+	                         {"daily": true} for today's puzzle, {"puzzle": 812} or {"date": "2023-09-08"} for a puzzle
+	                         from the history, a random answer without any of them
+	GET  /games/{id}         the state of the game
+	POST /games/{id}/guesses {"guess": "crane"}
+
+Each returns the HostGameState, the answer is only in the state once the game is over.  A game that is not used for
+the timeout is removed.  With a state file each new game and each guess is appended as a JSON line as it happens.
+The file is read back when the host starts, so games survive a restart, and rewritten without the games that
+timed out.
+*/
+
+type HostNewGameRequest struct {
+	Seed       int64  `json:"seed"`   // answer picked at random with the seed
+	Daily      bool   `json:"daily"`  // answer of today's puzzle
+	Puzzle     *int   `json:"puzzle"` // answer of the puzzle, 0 is the first puzzle
+	Date       string `json:"date"`   // answer of the puzzle on the date, YYYY-MM-DD
+	HardMode   bool   `json:"hard_mode"`
+	MaxGuesses int    `json:"max_guesses"` // default DefaultMaxGuesses
+}
+
+type HostGuessRequest struct {
+	Guess string `json:"guess"`
+}
+
+type HostGameState struct {
+	ID         string             `json:"id"`
+	Puzzle     *int               `json:"puzzle,omitempty"` // nil if it is not a puzzle
+	HardMode   bool               `json:"hard_mode"`
+	MaxGuesses int                `json:"max_guesses"`
+	Guesses    []APIGuessFeedback `json:"guesses"`
+	Solved     bool               `json:"solved"`
+	Over       bool               `json:"over"`
+	Answer     string             `json:"answer,omitempty"` // once the game is over
+	Expires    time.Time          `json:"expires"`
+}
+
+type hostedGame struct {
+	game   *Game
+	puzzle *int // nil if it is not a puzzle
+	used   time.Time
+}
+
+// hostEvent is a line of the state file, a new game or a guess
+type hostEvent struct {
+	ID         string    `json:"id"`
+	Time       time.Time `json:"time"`
+	Answer     string    `json:"answer,omitempty"` // new game
+	Puzzle     *int      `json:"puzzle,omitempty"`
+	HardMode   bool      `json:"hard_mode,omitempty"`
+	MaxGuesses int       `json:"max_guesses,omitempty"`
+	Guess      string    `json:"guess,omitempty"` // guess
+}
+
+type GameHost struct {
+	Words   *WordLists
+	History *AnswerHistory // answers for the daily and puzzle games
+	Timeout time.Duration  // games not used for this long are removed
+	Now     func() time.Time
+	mu      sync.Mutex
+	games   map[string]*hostedGame
+	path    string   // state file, empty if the games are only in memory
+	file    *os.File // events are appended
+}
+
+func NewGameHost(words *WordLists, history *AnswerHistory, timeout time.Duration) *GameHost {
+	return &GameHost{Words: words, History: history, Timeout: timeout, Now: time.Now, games: make(map[string]*hostedGame)}
+}
+
+// Open reads the games from the state file, drops the ones that timed out and opens the file to append to, a missing
+// file is created
+func (h *GameHost) Open(path string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	in, err := os.Open(path)
+	if err == nil {
+		err = h.read(in)
+		in.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	h.expire()
+	h.path = path
+	return h.rewrite()
+}
+
+// read replays the events, a partial last line is skipped
+func (h *GameHost) read(in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+	line := 0
+	var partial error
+	for scanner.Scan() {
+		line++
+		if partial != nil {
+			return partial
+		}
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		event := hostEvent{}
+		if err := json.Unmarshal([]byte(text), &event); err != nil {
+			partial = fmt.Errorf("line %d: %w", line, err)
+			continue
+		}
+		if err := h.replay(event); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	return scanner.Err()
+}
+
+func (h *GameHost) replay(event hostEvent) error {
+	if event.Answer != "" {
+		game := NewGame(h.Words, WordleWord([]rune(event.Answer)), event.MaxGuesses)
+		game.HardMode = event.HardMode
+		h.games[event.ID] = &hostedGame{game, event.Puzzle, event.Time}
+		return nil
+	}
+	hosted, ok := h.games[event.ID]
+	if !ok {
+		return fmt.Errorf("guess for unknown game %s", event.ID)
+	}
+	guess, err := ParseGuess(event.Guess)
+	if err != nil {
+		return err
+	}
+	if _, err := hosted.game.Guess(guess); err != nil {
+		return err
+	}
+	hosted.used = event.Time
+	return nil
+}
+
+// events are the events that recreate the game
+func (hg *hostedGame) events(id string) []hostEvent {
+	ret := []hostEvent{{ID: id, Time: hg.used, Answer: string(hg.game.Answer[:]), Puzzle: hg.puzzle,
+		HardMode: hg.game.HardMode, MaxGuesses: hg.game.MaxGuesses}}
+	for _, ga := range hg.game.Guesses {
+		ret = append(ret, hostEvent{ID: id, Time: hg.used, Guess: string(ga.Guess[:])})
+	}
+	return ret
+}
+
+// rewrite writes the state file with just the games in memory and opens it to append to, the caller holds the lock
+func (h *GameHost) rewrite() error {
+	temp := h.path + ".tmp"
+	out, err := os.Create(temp)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(out)
+	encoder := json.NewEncoder(w)
+	for id, hosted := range h.games {
+		for _, event := range hosted.events(id) {
+			if err := encoder.Encode(event); err != nil {
+				out.Close()
+				return err
+			}
+		}
+	}
+	if err := w.Flush(); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(temp, h.path); err != nil {
+		return err
+	}
+	if h.file != nil {
+		h.file.Close()
+	}
+	h.file, err = appendLines(h.path)
+	return err
+}
+
+// writeEvent appends the event to the state file, the caller holds the lock
+func (h *GameHost) writeEvent(event hostEvent) error {
+	if h.file == nil {
+		return nil
+	}
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = h.file.Write(append(line, '\n'))
+	return err
+}
+
+// Close closes the state file
+func (h *GameHost) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.file == nil {
+		return nil
+	}
+	err := h.file.Close()
+	h.file = nil
+	return err
+}
+
+// Len is the number of games
+func (h *GameHost) Len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.games)
+}
+
+// Expire removes the games that were not used for the timeout and rewrites the state file without them, returns the
+// number removed
+func (h *GameHost) Expire() (int, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	removed := h.expire()
+	if removed == 0 || h.file == nil {
+		return removed, nil
+	}
+	return removed, h.rewrite()
+}
+
+// expire removes the games that timed out, the caller holds the lock
+func (h *GameHost) expire() int {
+	removed := 0
+	now := h.Now()
+	for id, hosted := range h.games {
+		if now.Sub(hosted.used) > h.Timeout {
+			delete(h.games, id)
+			removed++
+		}
+	}
+	return removed
+}
+
+// ExpireEvery calls Expire every interval until stop is called
+func (h *GameHost) ExpireEvery(interval time.Duration) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan bool)
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := h.Expire(); err != nil {
+					fmt.Fprintln(os.Stderr, "host:", err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		ticker.Stop()
+		close(done)
+	}
+}
+
+// answer is the answer for the request and the puzzle number, nil if it is not a puzzle
+func (h *GameHost) answer(request HostNewGameRequest) (WordleWord, *int, error) {
+	choices := 0
+	for _, chosen := range []bool{request.Seed != 0, request.Daily, request.Puzzle != nil, request.Date != ""} {
+		if chosen {
+			choices++
+		}
+	}
+	if choices > 1 {
+		return WordleWord{}, nil, fmt.Errorf("only one of seed, daily, puzzle and date")
+	}
+	var puzzle int
+	isPuzzle := request.Puzzle != nil || request.Daily || request.Date != ""
+	if request.Puzzle != nil {
+		puzzle = *request.Puzzle
+	} else if request.Daily {
+		puzzle = PuzzleNumber(h.Now())
+	} else if request.Date != "" {
+		var err error
+		if puzzle, err = ParsePuzzleDate(request.Date); err != nil {
+			return WordleWord{}, nil, err
+		}
+	}
+	if isPuzzle {
+		answer, ok := h.History.Answers[puzzle]
+		if !ok {
+			return WordleWord{}, nil, fmt.Errorf("no answer for puzzle %d in the history", puzzle)
+		}
+		ret, err := ParseGuess(answer)
+		if err != nil || !h.Words.ValidGuess(ret) {
+			return WordleWord{}, nil, fmt.Errorf("the answer for puzzle %d is not in the guess list", puzzle)
+		}
+		return ret, &puzzle, nil
+	}
+	if request.Seed != 0 {
+		return h.Words.Answers[mathrand.New(mathrand.NewSource(request.Seed)).Intn(len(h.Words.Answers))], nil, nil
+	}
+	return h.Words.Answers[mathrand.Intn(len(h.Words.Answers))], nil, nil
+}
+
+func newGameID() string {
+	id := make([]byte, 8)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// state of the game, the caller holds the lock
+func (h *GameHost) state(id string, hosted *hostedGame) HostGameState {
+	game := hosted.game
+	ret := HostGameState{ID: id, Puzzle: hosted.puzzle, HardMode: game.HardMode, MaxGuesses: game.MaxGuesses,
+		Guesses: []APIGuessFeedback{}, Solved: game.Solved(), Over: game.Over(), Expires: hosted.used.Add(h.Timeout)}
+	for _, ga := range game.Guesses {
+		ret.Guesses = append(ret.Guesses, APIGuessFeedback{string(ga.Guess[:]), string(ga.Answer[:])})
+	}
+	if ret.Over {
+		ret.Answer = string(game.Answer[:])
+	}
+	return ret
+}
+
+// NewGame starts a game
+func (h *GameHost) NewGame(request HostNewGameRequest) (HostGameState, error) {
+	if request.MaxGuesses == 0 {
+		request.MaxGuesses = DefaultMaxGuesses
+	}
+	if request.MaxGuesses < 1 || request.MaxGuesses > 100 {
+		return HostGameState{}, fmt.Errorf("max_guesses %d is not 1 to 100", request.MaxGuesses)
+	}
+	answer, puzzle, err := h.answer(request)
+	if err != nil {
+		return HostGameState{}, err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	id := newGameID()
+	hosted := &hostedGame{NewGame(h.Words, answer, request.MaxGuesses), puzzle, h.Now()}
+	hosted.game.HardMode = request.HardMode
+	if err := h.writeEvent(hosted.events(id)[0]); err != nil {
+		return HostGameState{}, apiStatusError{http.StatusInternalServerError, err}
+	}
+	h.games[id] = hosted
+	return h.state(id, hosted), nil
+}
+
+// game is the game with the id, the caller holds the lock
+func (h *GameHost) game(id string) (*hostedGame, error) {
+	hosted, ok := h.games[id]
+	if !ok {
+		return nil, apiStatusError{http.StatusNotFound, fmt.Errorf("no game %s, it may have timed out", id)}
+	}
+	return hosted, nil
+}
+
+// State is the state of the game, looking at a game keeps it from timing out
+func (h *GameHost) State(id string) (HostGameState, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	hosted, err := h.game(id)
+	if err != nil {
+		return HostGameState{}, err
+	}
+	hosted.used = h.Now()
+	return h.state(id, hosted), nil
+}
+
+// Guess makes a guess in the game, an invalid guess is an error
+func (h *GameHost) Guess(id string, guess string) (HostGameState, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	hosted, err := h.game(id)
+	if err != nil {
+		return HostGameState{}, err
+	}
+	guessWW, err := ParseGuess(guess)
+	if err != nil {
+		return HostGameState{}, err
+	}
+	if _, err := hosted.game.Guess(guessWW); err != nil {
+		return HostGameState{}, err
+	}
+	hosted.used = h.Now()
+	if err := h.writeEvent(hostEvent{ID: id, Time: hosted.used, Guess: guess}); err != nil {
+		return HostGameState{}, apiStatusError{http.StatusInternalServerError, err}
+	}
+	return h.state(id, hosted), nil
+}
+
+// Handler serves the endpoints
+func (h *GameHost) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /games", apiHandler(h.NewGame))
+	mux.HandleFunc("GET /games/{id}", func(w http.ResponseWriter, r *http.Request) {
+		state, err := h.State(r.PathValue("id"))
+		writeResponse(w, state, err)
+	})
+	mux.HandleFunc("POST /games/{id}/guesses", func(w http.ResponseWriter, r *http.Request) {
+		request := HostGuessRequest{}
+		if err := decodeRequest(w, r, &request); err != nil {
+			writeJSON(w, http.StatusBadRequest, APIError{"bad request: " + err.Error()})
+			return
+		}
+		state, err := h.Guess(r.PathValue("id"), request.Guess)
+		writeResponse(w, state, err)
+	})
+	return mux
+}
@@ -1,6 +1,10 @@
 package gowordle
 
 import (
+	"sort"
+	"sync"
+	"sync/atomic"
+
 	"github.com/bits-and-blooms/bitset"
 )
 
@@ -18,16 +22,35 @@ func VerifyWordsAreSorted(words []WordleWord) {
 }
 
 /*
-letters['a'][0] all words whose first letter is an a, [1] second letter is an a, ...
+letters[0][0] all words whose first letter is an a, letters[1][0] second letter is an a, ...
 
-a word is represented by it's index into words
+a word is represented by it's index into words, letters by their index in the alphabet like WordCode
 */
 type WordleWord [5]rune
 type WordleMatcher struct {
 	words   []WordleWord
-	letters [5]map[rune]*bitset.BitSet // letters[0]['a'] set of words with first letter 'a'
-	count   map[rune][]*bitset.BitSet  // count['a'][0] set of words with 1 or more a, count['b'][1] words with 2 or more b
+	letters [5][26]*bitset.BitSet // letters[0][0] set of words with first letter 'a', nil if there are none
+	count   [26][]*bitset.BitSet  // count[0][0] set of words with 1 or more a, count[1][1] words with 2 or more b
 	id      int
+
+	contentHash     string // see ContentHash
+	contentHashOnce sync.Once
+}
+
+// ContentHash is the HashWords of the sorted words, the same for any order of the same words.  Unlike id it is the
+// same from one run to the next.
+func (wd *WordleMatcher) ContentHash() string {
+	wd.contentHashOnce.Do(func() {
+		wd.contentHash = contentHash(wd.words)
+	})
+	return wd.contentHash
+}
+
+// contentHash is the HashWords of the sorted words
+func contentHash(wordleWords []WordleWord) string {
+	words := WordleWordsToStrings(wordleWords)
+	sort.Strings(words)
+	return HashWords(words)
 }
 
 var cachedWordleMatchers map[string]*WordleMatcher = make(map[string]*WordleMatcher)
@@ -50,33 +73,48 @@ func findWordleMatcher1(words []WordleWord) (*WordleMatcher, bool) {
 	return ret, false
 }
 
-type WordleWordStruct struct {
-	word WordleWord
-}
 type WordleMatcherAtDepth struct {
 	matcher *WordleMatcher
-	deeper  map[WordleWordStruct]*WordleMatcherAtDepth
+	deeper  map[WordCode]*WordleMatcherAtDepth
 }
 
 var depthMatchers *WordleMatcherAtDepth
 
 var depthMatcherHitCount int
 
+// matcherLock guards depthMatchers, matchers are built with the write lock and only read once they are built so
+// games running in parallel find the built matchers with the read lock
+var matcherLock sync.RWMutex
+
 func init() {
 	depthMatchers = &WordleMatcherAtDepth{
-		deeper: make(map[WordleWordStruct]*WordleMatcherAtDepth),
+		deeper: make(map[WordCode]*WordleMatcherAtDepth),
 	}
 }
 
+// lookupWordleMatcher is the built matcher for the words, nil if there is none.  It does not change depthMatchers.
+func lookupWordleMatcher(words []WordleWord) *WordleMatcher {
+	depth := depthMatchers
+	for _, word := range words {
+		deeper, ok := depth.deeper[EncodeWord(word)]
+		if !ok {
+			return nil
+		}
+		depth = deeper
+	}
+	return depth.matcher
+}
+
 func findWordleMatcher(words []WordleWord) (*WordleMatcher, bool) {
 	depth := depthMatchers
 	for _, word := range words {
-		if deeper, ok := depth.deeper[WordleWordStruct{word}]; !ok {
+		code := EncodeWord(word)
+		if deeper, ok := depth.deeper[code]; !ok {
 			// map does not contain the word, so create and add
 			nextDeeper := &WordleMatcherAtDepth{
-				deeper: make(map[WordleWordStruct]*WordleMatcherAtDepth),
+				deeper: make(map[WordCode]*WordleMatcherAtDepth),
 			}
-			depth.deeper[WordleWordStruct{word}] = nextDeeper
+			depth.deeper[code] = nextDeeper
 			depth = nextDeeper
 		} else {
 			depth = deeper
@@ -98,19 +136,25 @@ func findWordleMatcher(words []WordleWord) (*WordleMatcher, bool) {
 // take a slice of strings and make wordle words
 func NewWordleMatcher(words []WordleWord) *WordleMatcher {
 	// VerifyWordsAreSorted(words)
+	matcherLock.RLock()
+	ret := lookupWordleMatcher(words)
+	matcherLock.RUnlock()
+	if ret != nil {
+		return ret
+	}
+	matcherLock.Lock()
+	defer matcherLock.Unlock()
 	ret, ok := findWordleMatcher(words)
 	if ok {
 		return ret
 	}
-	ret.count = make(map[rune][]*bitset.BitSet, 26)
 	for w, word := range words {
-		word_letters := make(map[rune]int, 5)
-		for l, letter := range word {
+		code := EncodeWord(word)
+		word_letters := [26]int{}
+		for l := range word {
 			// letters
-			if ret.letters[l] == nil {
-				ret.letters[l] = make(map[rune]*bitset.BitSet)
-			}
-			if _, ok := ret.letters[l][letter]; !ok {
+			letter := code.Letter(l)
+			if ret.letters[l][letter] == nil {
 				ret.letters[l][letter] = bitset.New(uint(len(words)))
 			}
 			ret.letters[l][letter].Set(uint(w))
@@ -119,10 +163,7 @@ func NewWordleMatcher(words []WordleWord) *WordleMatcher {
 		// count
 		for letter, count := range word_letters {
 			for c := 0; c < count; c++ {
-				if ret.count[letter] == nil {
-					ret.count[letter] = make([]*bitset.BitSet, 1) // [0]
-					ret.count[letter][0] = bitset.New(uint(len(words)))
-				} else if len(ret.count[letter]) <= count {
+				if len(ret.count[letter]) <= c {
 					ret.count[letter] = append(ret.count[letter], bitset.New(uint(len(words))))
 				}
 				ret.count[letter][c].Set(uint(w))
@@ -142,6 +183,7 @@ type LetterMatch struct {
 	must_not map[rune]int // eliminate all words with this many (or more) of the letter, 0 means 1 or more
 }
 
+// MakeLetterMatch panics if the answer colors are not g, y and r, other notations must go through ParseFeedback
 func MakeLetterMatch(guess, answer WordleWord) LetterMatch {
 	ret := LetterMatch{}
 	yellow_green := make(map[rune]int, 5)
@@ -153,8 +195,10 @@ func MakeLetterMatch(guess, answer WordleWord) LetterMatch {
 		} else if answer[index] == 'y' {
 			yellow_green[letter] = yellow_green[letter] + 1
 			ret.must[letter] = ret.must[letter] + 1
-		} else { // r
+		} else if answer[index] == 'r' {
 			ret.must_not[letter] = 0
+		} else {
+			panic("answer colors must be g, y or r, see ParseFeedback:" + string(answer[:]))
 		}
 	}
 	// The number of red letters not found in the word depends on how many green/yellow
@@ -190,64 +234,74 @@ type Answer struct {
 	mustNot []LetterCount
 }
 
-var Hitmiss map[string]Answer = make(map[string]Answer, 10000)
-var HitCount int
-var MissCount int
+// Hitmiss is the Answer for each guess and feedback, see hitmissKey.  The feedback is computed from the codes of
+// the solution and the guess so a hit does not build anything.
+var Hitmiss map[uint64]Answer = make(map[uint64]Answer, 10000)
+var HitCount int64
+var MissCount int64
+
+// hitmissKey is the guess code above the feedback code
+func hitmissKey(guess WordCode, feedback FeedbackCode) uint64 {
+	return uint64(guess)<<8 | uint64(feedback)
+}
+
+// hitmissLock guards Hitmiss, a hit only takes the read lock.  The counts are atomic.
+var hitmissLock sync.RWMutex
+
+// HitmissStats are the hits and misses of the Hitmiss cache
+func HitmissStats() (int, int) {
+	return int(atomic.LoadInt64(&HitCount)), int(atomic.LoadInt64(&MissCount))
+}
 
 func WordleAnswer2(solution, guess WordleWord) Answer {
-	key := string(solution[:]) + string(guess[:])
-	if ret, ok := Hitmiss[key]; ok {
-		HitCount++
+	guessCode := EncodeWord(guess)
+	feedback := Feedback(EncodeWord(solution), guessCode)
+	key := hitmissKey(guessCode, feedback)
+	hitmissLock.RLock()
+	ret, ok := Hitmiss[key]
+	hitmissLock.RUnlock()
+	if ok {
+		atomic.AddInt64(&HitCount, 1)
 		return ret
 	}
 
-	ret := Answer{
-		guess: guess,
-		// must:    []LetterCount{},
-		// mustNot: []LetterCount{},
+	ret = Answer{
+		guess:   guess,
 		must:    make([]LetterCount, 0, 5),
 		mustNot: make([]LetterCount, 0, 5),
-		Colors:  WordleWord([]rune{'r', 'r', 'r', 'r', 'r'}),
+		Colors:  feedback.Colors(),
 	}
-	solutionNotGreenCount := [26]int{}
 	guessYellowGreenCount := [26]int{}
-	must := [26]bool{}
-	mustNot := [26]bool{}
-	for i, solutionLetter := range solution {
-		guessLetter := guess[i]
-		if solutionLetter == guessLetter {
-			ret.Colors[i] = 'g'
-			guessYellowGreenCount[guessLetter-'a'] = guessYellowGreenCount[guessLetter-'a'] + 1
-		} else {
-			// answer[i] = 'r'
-			solutionNotGreenCount[solutionLetter-'a'] = solutionNotGreenCount[solutionLetter-'a'] + 1
-		}
-	}
-	// turn the red to yellow if in the word but not green
-	for i, guessLetter := range guess {
-		if ret.Colors[i] == 'r' {
-			if solutionNotGreenCount[guessLetter-'a'] > 0 {
-				ret.Colors[i] = 'y'
-				solutionNotGreenCount[guessLetter-'a'] = solutionNotGreenCount[guessLetter-'a'] - 1
-				guessYellowGreenCount[guessLetter-'a'] = guessYellowGreenCount[guessLetter-'a'] + 1
-			}
+	for i := range guess {
+		if ret.Colors[i] != 'r' {
+			guessYellowGreenCount[guessCode.Letter(i)]++
 		}
 	}
+	must := [26]bool{}
+	mustNot := [26]bool{}
 	for i, guessLetter := range guess {
+		letter := guessCode.Letter(i)
 		if ret.Colors[i] == 'r' {
-			if !mustNot[guessLetter-'a'] {
-				ret.mustNot = append(ret.mustNot, LetterCount{guessLetter, guessYellowGreenCount[guessLetter-'a']})
-				mustNot[guessLetter-'a'] = true
+			if !mustNot[letter] {
+				ret.mustNot = append(ret.mustNot, LetterCount{guessLetter, guessYellowGreenCount[letter]})
+				mustNot[letter] = true
 			}
 		} else if ret.Colors[i] == 'y' {
-			if !must[guessLetter-'a'] {
+			if !must[letter] {
 				// add one for each red letter
-				ret.must = append(ret.must, LetterCount{guessLetter, guessYellowGreenCount[guessLetter-'a'] - 1})
-				must[guessLetter-'a'] = true
+				ret.must = append(ret.must, LetterCount{guessLetter, guessYellowGreenCount[letter] - 1})
+				must[letter] = true
 			}
 		}
 	}
-	MissCount++
+	hitmissLock.Lock()
+	defer hitmissLock.Unlock()
+	if built, ok := Hitmiss[key]; ok {
+		// another game built it first
+		atomic.AddInt64(&HitCount, 1)
+		return built
+	}
+	atomic.AddInt64(&MissCount, 1)
 	Hitmiss[key] = ret
 	return ret
 }
@@ -271,12 +325,15 @@ func (wd *WordleMatcher) MatchingWithCache(solution, guess WordleWord) []WordleW
 
 // [0] is BitSet for 1 bit.  Index off by 1
 var bitsetAllSetPreAllocated []*bitset.BitSet = make([]*bitset.BitSet, 0)
+var bitsetAllSetLock sync.Mutex
 
 // Length is 1..N
 func NewBitsetAllSet(length int) *bitset.BitSet {
 	if length < 1 {
 		panic("bad length")
 	}
+	bitsetAllSetLock.Lock()
+	defer bitsetAllSetLock.Unlock()
 	for i := len(bitsetAllSetPreAllocated); i < length; i++ {
 		bitsetAllSetPreAllocated = append(bitsetAllSetPreAllocated, bitset.New(uint(i+1)).Complement())
 	}
@@ -295,56 +352,43 @@ func (wd *WordleMatcher) matchingWorker(guess, answer WordleWord, must, must_not
 		panic("not 5 letter word:" + string(answer[:]))
 	}
 	ret := NewBitsetAllSet(len(wd.words))
+	code := EncodeWord(guess)
 	// if there are greens then the starting point only contains words with matching letter
 	for i, color := range answer {
 		if color == 'g' {
-			set := wd.letters[i][guess[i]]
+			set := wd.letters[i][code.Letter(i)]
+			if set == nil {
+				return []WordleWord{} // no word has the green letter here
+			}
 			ret.InPlaceIntersection(set)
 		}
 	}
 
 	// must letter is for yellow letters.  It indicates how many of these letters
-	// must be in the word
+	// must be in the word, none of the words have that many if there is no count for it
 	for _, letterCount := range must {
-		yellow := letterCount.letter
-		count := letterCount.count
-		if counts, ok := wd.count[yellow]; ok {
-			if len(counts) > count {
-				set := counts[count]
-				ret.InPlaceIntersection(set)
-			}
+		counts := wd.count[letterCount.letter-'a']
+		if len(counts) <= letterCount.count {
+			return []WordleWord{}
 		}
+		ret.InPlaceIntersection(counts[letterCount.count])
 	}
 
 	// red letters removes words that do not contain the required count of matching letters
 	for _, letterCount := range must_not {
-		red := letterCount.letter
-		count := letterCount.count
-		if counts, ok := wd.count[red]; ok {
-			if len(counts) > count {
-				set := counts[count]
-				ret.InPlaceDifference(set)
-			}
+		counts := wd.count[letterCount.letter-'a']
+		if len(counts) > letterCount.count {
+			ret.InPlaceDifference(counts[letterCount.count])
 		}
 	}
 
 	// if there are yellow remove the words with matching letters - those would have been green
 	// also remove any words that have the red letter in the same index
 	for l, color := range answer {
-		if color == 'y' {
+		if color == 'y' || color == 'r' {
 			// words may not exist with this letter
-			if wd.letters[l] != nil {
-				if set, ok := wd.letters[l][guess[l]]; ok {
-					ret.InPlaceDifference(set)
-				}
-			}
-		}
-		if color == 'r' {
-			// words may not exist with this letter
-			if wd.letters[l] != nil {
-				if set, ok := wd.letters[l][guess[l]]; ok {
-					ret.InPlaceDifference(set)
-				}
+			if set := wd.letters[l][code.Letter(l)]; set != nil {
+				ret.InPlaceDifference(set)
 			}
 		}
 	}
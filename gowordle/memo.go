@@ -0,0 +1,330 @@
+package gowordle
+
+import (
+	"bufio"
+	"bytes"
+	"container/heap"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+/*
+The solver memo remembers the expensive results, like the best guess for a set of possible words, keyed by the
+content of the words instead of the process local WordleMatcher id.  A memo file is JSON lines, one MemoEntry
+per line.  Loading the file and appending each new entry as it is found lets later runs, or other people,
+pick up where a run left off.  Entries are never changed once they are written, a later duplicate is ignored.
+*/
+
+// Kinds of memo entries
+const (
+	MemoRecursive = "recursive" // ScoreAlgorithmRecursive best guesses
+	MemoMatches   = "matches"   // ScoreAlgorithmTotalMatches1Level best guess
+	MemoRanking   = "ranking"   // RankGuesses, every guess lowest score first
+)
+
+type MemoEntry struct {
+	Key    string   `json:"key"`    // kind:guesses hash:possible words hash
+	Size   int      `json:"size"`   // number of possible words
+	Words  []string `json:"words"`  // the guesses
+	Scores []int    `json:"scores"` // Scores[i] score of Words[i]
+}
+
+// Kind is the kind part of the key
+func (me MemoEntry) Kind() string {
+	kind, _, _ := strings.Cut(me.Key, ":")
+	return kind
+}
+
+// GuessesHash is the guess list part of the key
+func (me MemoEntry) GuessesHash() string {
+	parts := strings.Split(me.Key, ":")
+	if len(parts) != 3 {
+		return ""
+	}
+	return parts[1]
+}
+
+type Memo struct {
+	mu      sync.Mutex
+	entries map[string]MemoEntry
+	path    string   // memo file, empty if the memo is only in memory
+	file    *os.File // new entries are appended
+	Loaded  int      // entries read from the file
+	Added   int      // entries found since it was loaded
+}
+
+// SolverMemo is used by the strategies, starts in memory, see OpenMemoFile
+var SolverMemo = NewMemo()
+
+func NewMemo() *Memo {
+	return &Memo{entries: make(map[string]MemoEntry)}
+}
+
+// LoadMemoFile reads a memo file and opens it to append the new entries, a missing file is created
+func LoadMemoFile(path string) (*Memo, error) {
+	ret := NewMemo()
+	in, err := os.Open(path)
+	if err == nil {
+		err = ret.read(in)
+		in.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	ret.Loaded = len(ret.entries)
+	if ret.file, err = appendLines(path); err != nil {
+		return nil, err
+	}
+	ret.path = path
+	return ret, nil
+}
+
+// appendLines opens a JSON lines file to append to, creating it if needed.  A partial last line left by a run that
+// was killed is removed so the next line starts on its own line.
+func appendLines(path string) (*os.File, error) {
+	if data, err := os.ReadFile(path); err == nil && len(data) > 0 && data[len(data)-1] != '\n' {
+		if err := os.Truncate(path, int64(bytes.LastIndexByte(data, '\n')+1)); err != nil {
+			return nil, err
+		}
+	}
+	return os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+}
+
+// OpenMemoFile loads the memo file into SolverMemo, the entries found from now on are appended to the file
+func OpenMemoFile(path string) error {
+	memo, err := LoadMemoFile(path)
+	if err != nil {
+		return err
+	}
+	SolverMemo = memo
+	return nil
+}
+
+// read the entries, a partial last line from a run that was killed is skipped
+func (m *Memo) read(in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	line := 0
+	var partial error
+	for scanner.Scan() {
+		line++
+		if partial != nil {
+			return partial
+		}
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		entry := MemoEntry{}
+		if err := json.Unmarshal([]byte(text), &entry); err != nil {
+			partial = fmt.Errorf("line %d: %w", line, err)
+			continue
+		}
+		if len(entry.Words) == 0 || len(entry.Words) != len(entry.Scores) || strings.Count(entry.Key, ":") != 2 {
+			return fmt.Errorf("line %d: bad memo entry", line)
+		}
+		for _, word := range entry.Words {
+			if len([]rune(word)) != 5 {
+				return fmt.Errorf("line %d: bad memo word %q", line, word)
+			}
+		}
+		if _, ok := m.entries[entry.Key]; !ok {
+			m.entries[entry.Key] = entry
+		}
+	}
+	return scanner.Err()
+}
+
+// Close closes the memo file
+func (m *Memo) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.file == nil {
+		return nil
+	}
+	err := m.file.Close()
+	m.file = nil
+	return err
+}
+
+// Path is the memo file, empty if the memo is only in memory
+func (m *Memo) Path() string {
+	return m.path
+}
+
+func (m *Memo) Get(key string) (MemoEntry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ret, ok := m.entries[key]
+	return ret, ok
+}
+
+// Put remembers the entry and appends it to the memo file, an entry already in the memo is kept
+func (m *Memo) Put(entry MemoEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.entries[entry.Key]; ok {
+		return nil
+	}
+	m.entries[entry.Key] = entry
+	m.Added++
+	if m.file == nil {
+		return nil
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	_, err = m.file.Write(append(line, '\n'))
+	return err
+}
+
+func (m *Memo) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.entries)
+}
+
+// Entries sorted by key
+func (m *Memo) Entries() []MemoEntry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ret := make([]MemoEntry, 0, len(m.entries))
+	for _, entry := range m.entries {
+		ret = append(ret, entry)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Key < ret[j].Key })
+	return ret
+}
+
+// Prune removes the entries that keep returns false for and rewrites the memo file without them, returns the number
+// removed.  Duplicates and partial lines in the file are dropped too.
+func (m *Memo) Prune(keep func(MemoEntry) bool) (int, error) {
+	removed := 0
+	for _, entry := range m.Entries() {
+		if !keep(entry) {
+			removed++
+			m.mu.Lock()
+			delete(m.entries, entry.Key)
+			m.mu.Unlock()
+		}
+	}
+	if m.path == "" {
+		return removed, nil
+	}
+	entries := m.Entries()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	temp := m.path + ".tmp"
+	out, err := os.Create(temp)
+	if err != nil {
+		return removed, err
+	}
+	w := bufio.NewWriter(out)
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			out.Close()
+			return removed, err
+		}
+		w.Write(append(line, '\n'))
+	}
+	if err := w.Flush(); err != nil {
+		out.Close()
+		return removed, err
+	}
+	if err := out.Close(); err != nil {
+		return removed, err
+	}
+	if m.file != nil {
+		m.file.Close()
+	}
+	if err := os.Rename(temp, m.path); err != nil {
+		return removed, err
+	}
+	m.file, err = os.OpenFile(m.path, os.O_APPEND|os.O_WRONLY, 0644)
+	return removed, err
+}
+
+// memoKey is the key for the kind of result for the possible words guessing from the guesses with the GuessList Hash.
+// The words are hashed sorted so the same set of words in any order has the same key.
+func memoKey(kind string, guessesHash string, possibleWords []WordleWord) string {
+	return kind + ":" + guessesHash + ":" + contentHash(possibleWords)
+}
+
+// memoEntry builds an entry with the same score for each of the words
+func memoEntry(key string, size int, score int, words []WordleWord) MemoEntry {
+	ret := MemoEntry{Key: key, Size: size, Words: WordleWordsToStrings(words), Scores: make([]int, len(words))}
+	for i := range ret.Scores {
+		ret.Scores[i] = score
+	}
+	return ret
+}
+
+// rememberBest puts the best guesses in the SolverMemo, nothing is remembered if there are no best guesses
+func rememberBest(key string, size int, score int, words []WordleWord) (int, []WordleWord) {
+	if len(words) > 0 {
+		putMemo(memoEntry(key, size, score, words))
+	}
+	return score, words
+}
+
+var memoWriteError sync.Once
+
+// putMemo puts the entry in the SolverMemo, an error writing the memo file is reported once and the memo keeps
+// working in memory.  An entry without words is not remembered, it can not be read back.
+func putMemo(entry MemoEntry) {
+	if len(entry.Words) == 0 {
+		return
+	}
+	if err := SolverMemo.Put(entry); err != nil {
+		memoWriteError.Do(func() { fmt.Fprintln(os.Stderr, "memo:", err) })
+	}
+}
+
+// RankGuesses scores every guess for the possible words and returns them lowest score first, remembered in the
+// SolverMemo since scoring every guess for all the answers is slow
+func RankGuesses(guesses GuessList, possibleWords []WordleWord) []Item {
+	ret, _ := RankGuessesContext(context.Background(), guesses, possibleWords, nil)
+	return ret
+}
+
+// RankGuessesContext is RankGuesses with a progress step for each guess scored, it stops with the context's error
+// when the context is cancelled
+func RankGuessesContext(ctx context.Context, guesses GuessList, possibleWords []WordleWord, progress *Progress) ([]Item, error) {
+	key := memoKey(MemoRanking, guesses.Hash, possibleWords)
+	if entry, ok := SolverMemo.Get(key); ok {
+		ret := make([]Item, len(entry.Words))
+		for i, word := range entry.Words {
+			ret[i] = Item{Value: WordleWord([]rune(word)), Score: entry.Scores[i]}
+		}
+		return ret, nil
+	}
+	scores := NewMinHeapWordleWordPriority()
+	for _, guess := range orderGuesses(guesses.Words, possibleWords) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		heap.Push(scores, Item{Value: guess, Score: GuessScore(guess, possibleWords, guesses.Words, 0)})
+		progress.Step()
+	}
+	ret := make([]Item, 0, scores.Len())
+	entry := MemoEntry{Key: key, Size: len(possibleWords), Words: []string{}, Scores: []int{}}
+	for scores.Len() > 0 {
+		item := heap.Pop(scores).(Item)
+		ret = append(ret, item)
+		entry.Words = append(entry.Words, string(item.Value[:]))
+		entry.Scores = append(entry.Scores, item.Score)
+	}
+	putMemo(entry)
+	return ret, nil
+}
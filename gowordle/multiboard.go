@@ -0,0 +1,134 @@
+package gowordle
+
+import (
+	"container/heap"
+)
+
+/*
+Multi board games (Dordle, Quordle, Octordle): each guess is played on every board at once.
+Each board keeps its own set of possible words.
+*/
+
+// MultiBoardMaxGuesses is the usual number of guesses allowed for the number of boards: 7 for 2, 9 for 4, 13 for 8
+func MultiBoardMaxGuesses(boards int) int {
+	return boards + 5
+}
+
+type MultiBoard struct {
+	Boards  [][]WordleWord // Boards[i] possible words remaining for board i
+	Solved  []bool
+	Guesses []WordleWord
+}
+
+func NewMultiBoard(possibleWords []WordleWord, boards int) *MultiBoard {
+	ret := &MultiBoard{
+		Boards: make([][]WordleWord, boards),
+		Solved: make([]bool, boards),
+	}
+	for i := range ret.Boards {
+		ret.Boards[i] = possibleWords
+	}
+	return ret
+}
+
+// Apply narrows down every unsolved board with the answer colors for the guess, answers[i] is the answer for board i.
+// The answers for solved boards are ignored.
+func (mb *MultiBoard) Apply(guess WordleWord, answers []WordleWord) {
+	if len(answers) != len(mb.Boards) {
+		panic("need an answer for each board")
+	}
+	mb.Guesses = append(mb.Guesses, guess)
+	for i, answer := range answers {
+		if mb.Solved[i] {
+			continue
+		}
+		if answer == allGreen {
+			mb.Solved[i] = true
+			mb.Boards[i] = []WordleWord{guess}
+			continue
+		}
+		game := NewWordleMatcher(mb.Boards[i])
+		mb.Boards[i] = game.Matching(guess, answer)
+	}
+}
+
+// Unsolved returns the indexes of the boards that have not been solved
+func (mb *MultiBoard) Unsolved() []int {
+	ret := []int{}
+	for i, solved := range mb.Solved {
+		if !solved {
+			ret = append(ret, i)
+		}
+	}
+	return ret
+}
+
+func (mb *MultiBoard) Done() bool {
+	return len(mb.Unsolved()) == 0
+}
+
+// MultiBoardScoreAll scores each guess by the combined GuessScore of all the unsolved boards, lower is better.
+func MultiBoardScoreAll(allWords []WordleWord, mb *MultiBoard) *MinHeap[Item] {
+	ret := NewMinHeapWordleWordPriority()
+	unsolved := mb.Unsolved()
+	if len(unsolved) == 0 {
+		panic("all boards are solved")
+	}
+	for _, guess := range allWords {
+		score := 0
+		for _, board := range unsolved {
+			score += GuessScore(guess, mb.Boards[board], allWords, 1)
+		}
+		heap.Push(ret, Item{Value: guess, Score: score})
+	}
+	return ret
+}
+
+// NextGuessMultiBoard returns the best guess for all the unsolved boards.
+// A board that is down to one possible word is guessed right away, it has to be guessed eventually.
+func NextGuessMultiBoard(allWords []WordleWord, mb *MultiBoard) WordleWord {
+	for _, board := range mb.Unsolved() {
+		if len(mb.Boards[board]) == 1 {
+			return mb.Boards[board][0]
+		}
+	}
+	ret := heap.Pop(MultiBoardScoreAll(allWords, mb)).(Item)
+	return ret.Value
+}
+
+type MultiBoardResult struct {
+	Solutions []string
+	Guesses   []string
+	SolvedAt  []int // SolvedAt[i] the guess number (1 based) that solved board i, 0 if not solved
+	Solved    bool
+}
+
+// SimulateMultiBoard plays one game with a board for each of the solutions, stopping after maxGuesses
+func SimulateMultiBoard(words_s []string, solutions_s []string, first_guess_s string, maxGuesses int) MultiBoardResult {
+	return SimulateMultiBoardWordLists(NewWordLists(words_s, nil), solutions_s, first_guess_s, maxGuesses)
+}
+
+// SimulateMultiBoardWordLists plays one game guessing from the Guesses, each board starts with all the Answers
+func SimulateMultiBoardWordLists(wordLists *WordLists, solutions_s []string, first_guess_s string, maxGuesses int) MultiBoardResult {
+	solutions := StringsToWordleWords(solutions_s)
+	mb := NewMultiBoard(wordLists.Answers, len(solutions))
+	ret := MultiBoardResult{Solutions: solutions_s, SolvedAt: make([]int, len(solutions))}
+	guess := WordleWord([]rune(first_guess_s))
+	for guessCount := 1; guessCount <= maxGuesses; guessCount++ {
+		ret.Guesses = append(ret.Guesses, string(guess[:]))
+		answers := make([]WordleWord, len(solutions))
+		for i, solution := range solutions {
+			answers[i] = WordleAnswer(solution, guess)
+			if answers[i] == allGreen && ret.SolvedAt[i] == 0 {
+				ret.SolvedAt[i] = guessCount
+			}
+		}
+		mb.Apply(guess, answers)
+		if mb.Done() {
+			ret.Solved = true
+			return ret
+		}
+		guess = NextGuessMultiBoard(wordLists.Guesses, mb)
+	}
+	return ret
+}
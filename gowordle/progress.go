@@ -0,0 +1,228 @@
+package gowordle
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+/*
+Progress reports how a long job is going as ProgressEvents, it works when the output is not a terminal.  The events
+go to sinks, like ProgressJSONLines for a JSON line per event on stderr or a ProgressServer for Server-Sent Events:
+
+	{"job":"sim","state":"running","done":120,"total":2309,"failed":0,"average":3.61,"elapsed_seconds":4.2,
+	 "eta_seconds":76.6,"memo_entries":311,"cache_hits":50211,"cache_misses":9120,...}
+
+An event is sent at most every Interval while the job runs and once more when it is done or cancelled.
+*/
+
+// Progress event states
+const (
+	ProgressRunning   = "running"
+	ProgressDone      = "done"
+	ProgressCancelled = "cancelled"
+)
+
+type ProgressEvent struct {
+	Job         string    `json:"job"`
+	State       string    `json:"state"`
+	Time        time.Time `json:"time"`
+	Done        int       `json:"done"`
+	Total       int       `json:"total"`
+	Failed      int       `json:"failed"`            // games not solved
+	Average     float64   `json:"average,omitempty"` // average guesses of the solved games
+	Elapsed     float64   `json:"elapsed_seconds"`
+	ETA         float64   `json:"eta_seconds"` // estimate from the time per step so far
+	MemoEntries int       `json:"memo_entries"`
+	CacheHits   int       `json:"cache_hits"` // answer colors cache, see WordleAnswer2
+	CacheMisses int       `json:"cache_misses"`
+}
+
+type Progress struct {
+	Job      string
+	Total    int
+	Interval time.Duration // least time between running events
+	sinks    []func(ProgressEvent)
+	mu       sync.Mutex
+	start    time.Time
+	last     time.Time
+	done     int
+	solved   int
+	failed   int
+	guesses  int // of the solved games
+}
+
+// NewProgress starts the job, a nil *Progress does nothing so jobs can always report
+func NewProgress(job string, total int, sinks ...func(ProgressEvent)) *Progress {
+	return &Progress{Job: job, Total: total, Interval: time.Second, sinks: sinks, start: time.Now()}
+}
+
+// Game is a step of a job that plays games
+func (p *Progress) Game(game SimulateResult) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if game.Solved {
+		p.solved++
+		p.guesses += len(game.Guesses)
+	} else {
+		p.failed++
+	}
+	p.step()
+}
+
+// Step is a step of a job
+func (p *Progress) Step() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.step()
+}
+
+// step counts a step and sends an event if it has been Interval since the last one, the caller holds the lock
+func (p *Progress) step() {
+	p.done++
+	if now := time.Now(); now.Sub(p.last) >= p.Interval {
+		p.last = now
+		p.send(ProgressRunning)
+	}
+}
+
+// Finish sends the last event with the state, ProgressDone or ProgressCancelled
+func (p *Progress) Finish(state string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.send(state)
+}
+
+// send the event to the sinks, the caller holds the lock
+func (p *Progress) send(state string) {
+	now := time.Now()
+	event := ProgressEvent{Job: p.Job, State: state, Time: now, Done: p.done, Total: p.Total, Failed: p.failed,
+		Elapsed: now.Sub(p.start).Seconds(), MemoEntries: SolverMemo.Len()}
+	if p.solved > 0 {
+		event.Average = float64(p.guesses) / float64(p.solved)
+	}
+	if p.done > 0 && state == ProgressRunning {
+		event.ETA = event.Elapsed / float64(p.done) * float64(p.Total-p.done)
+	}
+	event.CacheHits, event.CacheMisses = HitmissStats()
+	for _, sink := range p.sinks {
+		sink(event)
+	}
+}
+
+// ProgressJSONLines writes each event as a JSON line
+func ProgressJSONLines(w io.Writer) func(ProgressEvent) {
+	var lock sync.Mutex
+	return func(event ProgressEvent) {
+		lock.Lock()
+		defer lock.Unlock()
+		if line, err := json.Marshal(event); err == nil {
+			w.Write(append(line, '\n'))
+		}
+	}
+}
+
+// ProgressServer streams the events to its clients as Server-Sent Events:
+//
+//	GET  /events  event: progress, data: the ProgressEvent as JSON, the stream ends after the done or cancelled event
+//	POST /cancel  cancels the job
+type ProgressServer struct {
+	Cancel  func()
+	mu      sync.Mutex
+	clients map[chan ProgressEvent]bool
+	last    *ProgressEvent // sent to a client when it connects
+}
+
+func NewProgressServer(cancel func()) *ProgressServer {
+	return &ProgressServer{Cancel: cancel, clients: make(map[chan ProgressEvent]bool)}
+}
+
+// Send is the sink for the events, a client that is behind misses running events instead of slowing the job down.
+// The done or cancelled event always gets to the client, the oldest event waiting for it is dropped to make room.
+func (s *ProgressServer) Send(event ProgressEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.last = &event
+	for client := range s.clients {
+		for sent := false; !sent; {
+			select {
+			case client <- event:
+				sent = true
+			default:
+				if event.State == ProgressRunning {
+					sent = true
+				} else {
+					// only Send writes to the client so there is room once one is read
+					select {
+					case <-client:
+					default:
+					}
+				}
+			}
+		}
+	}
+}
+
+func (s *ProgressServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /events", s.events)
+	mux.HandleFunc("POST /cancel", func(w http.ResponseWriter, r *http.Request) {
+		if s.Cancel != nil {
+			s.Cancel()
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+	return mux
+}
+
+func (s *ProgressServer) events(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	client := make(chan ProgressEvent, 16)
+	s.mu.Lock()
+	s.clients[client] = true
+	if s.last != nil {
+		client <- *s.last
+	}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.clients, client)
+		s.mu.Unlock()
+	}()
+	for {
+		select {
+		case event := <-client:
+			data, err := json.Marshal(event)
+			if err != nil {
+				return
+			}
+			if _, err := fmt.Fprintf(w, "event: progress\ndata: %s\n\n", data); err != nil {
+				return
+			}
+			flusher.Flush()
+			if event.State != ProgressRunning {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
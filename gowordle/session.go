@@ -0,0 +1,120 @@
+package gowordle
+
+import (
+	"container/heap"
+	"fmt"
+	"strings"
+)
+
+/*
+A session follows a game one guess at a time.  The possible answers after each guess are kept so a guess
+can be undone without starting over and each new guess only narrows down the current possible answers.
+*/
+
+type Session struct {
+	Words      *WordLists
+	FirstGuess WordleWord // suggested before any guesses, scoring every guess against every answer is slow
+	History    []GuessAnswer
+	possible   [][]WordleWord // possible[i] answers remaining after i guesses
+	suggested  map[int]WordleWord
+}
+
+func NewSession(words *WordLists, firstGuess WordleWord) *Session {
+	ret := &Session{Words: words, FirstGuess: firstGuess}
+	ret.Reset()
+	return ret
+}
+
+// Reset starts a new game
+func (s *Session) Reset() {
+	s.History = []GuessAnswer{}
+	s.possible = [][]WordleWord{s.Words.Answers}
+	s.suggested = make(map[int]WordleWord)
+}
+
+// Apply narrows down the possible answers, it is an error if no answers remain
+func (s *Session) Apply(guess, answer WordleWord) error {
+	matching := NewWordleMatcher(s.Possible()).Matching(guess, answer)
+	if len(matching) == 0 {
+		return fmt.Errorf("no possible answers for %s %s", string(guess[:]), string(answer[:]))
+	}
+	s.History = append(s.History, GuessAnswer{guess, answer})
+	s.possible = append(s.possible, matching)
+	return nil
+}
+
+// Replay is Apply that keeps a guess that leaves no possible answers so a history that contradicts itself can be
+// explained
+func (s *Session) Replay(guess, answer WordleWord) {
+	matching := s.Possible()
+	if len(matching) > 0 {
+		matching = NewWordleMatcher(matching).Matching(guess, answer)
+	}
+	s.History = append(s.History, GuessAnswer{guess, answer})
+	s.possible = append(s.possible, matching)
+}
+
+// Undo removes the last guess, false if there were no guesses
+func (s *Session) Undo() bool {
+	if len(s.History) == 0 {
+		return false
+	}
+	delete(s.suggested, len(s.History))
+	s.History = s.History[:len(s.History)-1]
+	s.possible = s.possible[:len(s.possible)-1]
+	return true
+}
+
+// Possible are the answers remaining
+func (s *Session) Possible() []WordleWord {
+	return s.possible[len(s.possible)-1]
+}
+
+// Suggest is the best next guess, FirstGuess if there have been no guesses
+func (s *Session) Suggest() WordleWord {
+	if len(s.History) == 0 {
+		return s.FirstGuess
+	}
+	if ret, ok := s.suggested[len(s.History)]; ok {
+		return ret
+	}
+	ret := nextGuess(BestGuess1, s.Words.GuessList(), s.Possible())
+	s.suggested[len(s.History)] = ret
+	return ret
+}
+
+// Top returns the n guesses with the lowest GuessScore, lowest first
+func (s *Session) Top(n int) []Item {
+	possible := s.Possible()
+	scores := ScoreAlgorithmTotalMatches1LevelAll(s.Words.Guesses, possible, possible, 1, len(possible))
+	ret := []Item{}
+	for i := 0; i < n && scores.Len() > 0; i++ {
+		ret = append(ret, heap.Pop(scores).(Item))
+	}
+	return ret
+}
+
+// Why explains if the word is still a possible answer or each guess and the rule that ruled it out
+func (s *Session) Why(word WordleWord) string {
+	name := string(word[:])
+	if !s.Words.ValidGuess(word) {
+		return fmt.Sprintf("%s is not in the guess list", name)
+	}
+	isAnswer := false
+	for _, answer := range s.Words.Answers {
+		isAnswer = isAnswer || answer == word
+	}
+	if !isAnswer {
+		return fmt.Sprintf("%s is a valid guess but not in the answer list", name)
+	}
+	if exclusions := ExclusionsByHistory(word, s.History); len(exclusions) > 0 {
+		reasons := make([]string, len(exclusions))
+		for i, exclusion := range exclusions {
+			reasons[i] = fmt.Sprintf("by guess %d %s %s: %s, %s would have been %s", exclusion.Turn,
+				string(exclusion.Guess[:]), FormatFeedback(exclusion.Feedback), exclusion, name,
+				FormatFeedback(WordleAnswer(word, exclusion.Guess)))
+		}
+		return fmt.Sprintf("%s was ruled out %s", name, strings.Join(reasons, "; and "))
+	}
+	return fmt.Sprintf("%s is one of the %d possible answers", name, len(s.Possible()))
+}
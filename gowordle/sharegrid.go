@@ -0,0 +1,112 @@
+package gowordle
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+/*
+Share grids are posted by players after a game:
+
+	Wordle 812 4/6*
+
+	⬛🟨⬛⬛⬛
+	⬛⬛🟩🟨⬛
+	🟩🟩🟩⬛⬛
+	🟩🟩🟩🟩🟩
+*/
+
+// shareGridColors maps the emoji squares to answer colors, the high contrast squares are included
+var shareGridColors = map[rune]rune{
+	'🟩': 'g',
+	'🟧': 'g', // high contrast green
+	'🟨': 'y',
+	'🟦': 'y', // high contrast yellow
+	'⬛': 'r',
+	'⬜': 'r', // light mode
+}
+
+var shareGridHeader = regexp.MustCompile(`Wordle\s+#?([0-9][0-9,.]*)\s+([1-9X])/([0-9]+)(\*?)`)
+
+type ShareGrid struct {
+	Puzzle     int          // puzzle number, 0 if there was no header
+	Guesses    int          // guesses from the header, 0 if the game was not solved (X)
+	MaxGuesses int          // guesses allowed from the header
+	HardMode   bool         // header ends in *
+	Rows       []WordleWord // answer colors for each guess
+}
+
+// Solved is true if the last row is all green
+func (sg ShareGrid) Solved() bool {
+	return len(sg.Rows) > 0 && sg.Rows[len(sg.Rows)-1] == allGreen
+}
+
+// ParseShareGrid turns a posted share grid into answer colors.  The header is optional, the rows can be
+// on separate lines or run together on one line.
+func ParseShareGrid(text string) (ShareGrid, error) {
+	ret := ShareGrid{}
+	if match := shareGridHeader.FindStringSubmatchIndex(text); match != nil {
+		puzzle := strings.NewReplacer(",", "", ".", "").Replace(text[match[2]:match[3]])
+		ret.Puzzle, _ = strconv.Atoi(puzzle)
+		if guesses := text[match[4]:match[5]]; guesses != "X" {
+			ret.Guesses, _ = strconv.Atoi(guesses)
+		}
+		ret.MaxGuesses, _ = strconv.Atoi(text[match[6]:match[7]])
+		ret.HardMode = match[9] > match[8]
+		text = text[match[1]:]
+	}
+	row := []rune{}
+	for _, r := range text {
+		if color, ok := shareGridColors[r]; ok {
+			row = append(row, color)
+			if len(row) == 5 {
+				ret.Rows = append(ret.Rows, WordleWord(row))
+				row = []rune{}
+			}
+		} else if r == '\n' && len(row) > 0 {
+			return ret, fmt.Errorf("row %d has %d squares, expected 5", len(ret.Rows)+1, len(row))
+		}
+	}
+	if len(row) > 0 {
+		return ret, fmt.Errorf("row %d has %d squares, expected 5", len(ret.Rows)+1, len(row))
+	}
+	if len(ret.Rows) == 0 {
+		return ret, fmt.Errorf("no rows found in share grid")
+	}
+	for i, colors := range ret.Rows[:len(ret.Rows)-1] {
+		if colors == allGreen {
+			return ret, fmt.Errorf("row %d is all green but is not the last row", i+1)
+		}
+	}
+	if ret.Guesses > 0 && ret.Guesses != len(ret.Rows) {
+		return ret, fmt.Errorf("header says %d guesses but there are %d rows", ret.Guesses, len(ret.Rows))
+	}
+	if ret.Guesses > 0 && !ret.Solved() {
+		return ret, fmt.Errorf("header says solved in %d but the last row is not all green", ret.Guesses)
+	}
+	return ret, nil
+}
+
+type ReverseRow struct {
+	Colors     WordleWord
+	Candidates []WordleWord // guesses that produce Colors against the answer
+	Unique     bool         // exactly one guess could have been played
+}
+
+// ReverseSolve lists every word in guessWords that could have produced each row of answer colors against the answer.
+func ReverseSolve(guessWords []WordleWord, answer WordleWord, rows []WordleWord) []ReverseRow {
+	ret := make([]ReverseRow, 0, len(rows))
+	for _, colors := range rows {
+		row := ReverseRow{Colors: colors, Candidates: []WordleWord{}}
+		for _, guess := range guessWords {
+			if WordleAnswer2(answer, guess).Colors == colors {
+				row.Candidates = append(row.Candidates, guess)
+			}
+		}
+		row.Unique = len(row.Candidates) == 1
+		ret = append(ret, row)
+	}
+	return ret
+}
@@ -0,0 +1,222 @@
+package gowordle
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+The stats file keeps the games a person played for NYT style statistics, JSON lines with a StatsGame per line:
+
+	{"date":"2026-10-19","puzzle":1948,"answer":"crane","guesses":["raise","crane"],"colors":["ryrrg","ggggg"],"solved":true}
+
+A streak is the games won on one day after another, a loss or a day without a game ends it.  The current streak
+is still going if the last game was today or yesterday.
+*/
+
+type StatsGame struct {
+	Date    string   `json:"date"` // YYYY-MM-DD
+	Puzzle  int      `json:"puzzle,omitempty"`
+	Answer  string   `json:"answer,omitempty"` // empty when the answer of a lost game is not known
+	Guesses []string `json:"guesses"`
+	Colors  []string `json:"colors"` // colors[i] feedback for guesses[i]
+	Solved  bool     `json:"solved"`
+}
+
+// NewStatsGame is the game with the answer and the guesses, the colors are computed.  It is solved if the last
+// guess is the answer.
+func NewStatsGame(date string, answer string, guesses []string) (StatsGame, error) {
+	if _, err := time.Parse(time.DateOnly, date); err != nil {
+		return StatsGame{}, fmt.Errorf("date %q must be YYYY-MM-DD", date)
+	}
+	solution, err := ParseGuess(answer)
+	if err != nil {
+		return StatsGame{}, fmt.Errorf("answer: %w", err)
+	}
+	if len(guesses) == 0 {
+		return StatsGame{}, fmt.Errorf("no guesses")
+	}
+	ret := StatsGame{Date: date, Answer: answer, Guesses: guesses, Colors: []string{}}
+	for i, guess := range guesses {
+		guessWW, err := ParseGuess(guess)
+		if err != nil {
+			return StatsGame{}, err
+		}
+		if ret.Solved {
+			return StatsGame{}, fmt.Errorf("guess %d %s is after the answer", i+1, guess)
+		}
+		colors := WordleAnswer(solution, guessWW)
+		ret.Colors = append(ret.Colors, string(colors[:]))
+		ret.Solved = colors == allGreen
+	}
+	return ret, nil
+}
+
+// key is the same for the same game played on the same day, imports skip games already in the store
+func (g StatsGame) key() string {
+	return g.Date + " " + g.Answer + " " + strings.Join(g.Guesses, " ")
+}
+
+type StatsStore struct {
+	Games []StatsGame // in the order they were added
+	mu    sync.Mutex
+	keys  map[string]bool
+	file  *os.File
+}
+
+// OpenStats reads the games in the file and opens it to add more, the file is created if it does not exist
+func OpenStats(path string) (*StatsStore, error) {
+	ret := &StatsStore{Games: []StatsGame{}, keys: map[string]bool{}}
+	in, err := os.Open(path)
+	if err == nil {
+		games, err := ReadStatsGames(in)
+		in.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		for _, game := range games {
+			ret.add(game)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if ret.file, err = appendLines(path); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// ReadStatsGames reads JSON lines of games, a partial last line is skipped
+func ReadStatsGames(in io.Reader) ([]StatsGame, error) {
+	ret := []StatsGame{}
+	scanner := bufio.NewScanner(in)
+	line := 0
+	var partial error
+	for scanner.Scan() {
+		line++
+		if partial != nil {
+			return nil, partial
+		}
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		game := StatsGame{}
+		if err := json.Unmarshal([]byte(text), &game); err != nil {
+			partial = fmt.Errorf("line %d: %w", line, err)
+			continue
+		}
+		if _, err := time.Parse(time.DateOnly, game.Date); err != nil || len(game.Guesses) == 0 {
+			return nil, fmt.Errorf("line %d: a game needs a YYYY-MM-DD date and guesses", line)
+		}
+		ret = append(ret, game)
+	}
+	return ret, scanner.Err()
+}
+
+// WriteStatsGames writes the games as JSON lines, the format of the stats file
+func WriteStatsGames(w io.Writer, games []StatsGame) error {
+	for _, game := range games {
+		line, err := json.Marshal(game)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// add remembers the game, false if it is already in the store.  The caller holds the lock.
+func (s *StatsStore) add(game StatsGame) bool {
+	if s.keys[game.key()] {
+		return false
+	}
+	s.keys[game.key()] = true
+	s.Games = append(s.Games, game)
+	return true
+}
+
+// Add writes the game to the file, false if the same game is already in the store
+func (s *StatsStore) Add(game StatsGame) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.add(game) {
+		return false, nil
+	}
+	return true, WriteStatsGames(s.file, []StatsGame{game})
+}
+
+// Import adds the games that are not already in the store and returns how many were added
+func (s *StatsStore) Import(games []StatsGame) (int, error) {
+	ret := 0
+	for _, game := range games {
+		added, err := s.Add(game)
+		if err != nil {
+			return ret, err
+		}
+		if added {
+			ret++
+		}
+	}
+	return ret, nil
+}
+
+func (s *StatsStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+type StatsSummary struct {
+	Played        int
+	Won           int
+	WinPercent    float64
+	CurrentStreak int
+	MaxStreak     int
+	Distribution  []int // Distribution[i] games won in i+1 guesses, at least DefaultMaxGuesses long
+}
+
+// Summarize is the statistics of the games on the day today, YYYY-MM-DD
+func Summarize(games []StatsGame, today string) StatsSummary {
+	ret := StatsSummary{Played: len(games), Distribution: make([]int, DefaultMaxGuesses)}
+	sorted := make([]StatsGame, len(games))
+	copy(sorted, games)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Date < sorted[j].Date })
+	streak := 0
+	last := time.Time{}
+	for _, game := range sorted {
+		day, _ := time.Parse(time.DateOnly, game.Date)
+		if !last.IsZero() && day.Sub(last) > 24*time.Hour {
+			streak = 0
+		}
+		last = day
+		if !game.Solved {
+			streak = 0
+			continue
+		}
+		ret.Won++
+		streak++
+		ret.MaxStreak = max(ret.MaxStreak, streak)
+		for len(ret.Distribution) < len(game.Guesses) {
+			ret.Distribution = append(ret.Distribution, 0)
+		}
+		ret.Distribution[len(game.Guesses)-1]++
+	}
+	if now, err := time.Parse(time.DateOnly, today); err == nil && !last.IsZero() && now.Sub(last) <= 24*time.Hour {
+		ret.CurrentStreak = streak
+	}
+	if ret.Played > 0 {
+		ret.WinPercent = 100 * float64(ret.Won) / float64(ret.Played)
+	}
+	return ret
+}
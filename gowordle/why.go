@@ -0,0 +1,123 @@
+package gowordle
+
+import (
+	"fmt"
+	"sort"
+)
+
+/*
+Explain why the matcher removed a word.  The rules are checked in the order of the steps in matchingWorker so the
+rule named is the step of the filter that removed the word:
+
+	green mismatch          a green letter is not in the same spot in the word
+	too few copies          must, the word has fewer of a yellow letter than the green and yellow copies in the guess
+	red letter present      must not, a red letter with no green or yellow copies is in the word
+	too many copies         must not, the word has more of a red letter than the green and yellow copies in the guess
+	yellow in the same spot a yellow letter is in the same spot in the word, it would have been green
+	red in the same spot    a red letter is in the same spot in the word
+*/
+
+// Rules for removing a word
+const (
+	RuleGreenMismatch = "green mismatch"
+	RuleTooFew        = "too few copies"
+	RuleRedPresent    = "red letter present"
+	RuleTooMany       = "too many copies"
+	RuleYellowSpot    = "yellow in the same spot"
+	RuleRedSpot       = "red in the same spot"
+)
+
+type Exclusion struct {
+	Turn     int // 1 based guess in the history
+	Guess    WordleWord
+	Feedback WordleWord
+	Rule     string
+	Letter   rune
+	Position int // 1 based spot for the green and same spot rules, 0 for the others
+	Count    int // copies of the letter required for too few, allowed for too many
+	Copies   int // copies of the letter in the word
+}
+
+func (e Exclusion) String() string {
+	letter := string(e.Letter)
+	switch e.Rule {
+	case RuleGreenMismatch:
+		return fmt.Sprintf("%s, spot %d must be %s", e.Rule, e.Position, letter)
+	case RuleTooFew:
+		return fmt.Sprintf("%s, needs at least %d %s but has %d", e.Rule, e.Count, letter, e.Copies)
+	case RuleRedPresent:
+		return fmt.Sprintf("%s, can not have %s but has %d", e.Rule, letter, e.Copies)
+	case RuleTooMany:
+		return fmt.Sprintf("%s, can have only %d %s but has %d", e.Rule, e.Count, letter, e.Copies)
+	default:
+		return fmt.Sprintf("%s, spot %d can not be %s", e.Rule, e.Position, letter)
+	}
+}
+
+// sortedLetterCounts are in letter order, MakeLetterMatch2 order is random
+func sortedLetterCounts(counts []LetterCount) []LetterCount {
+	sort.Slice(counts, func(i, j int) bool { return counts[i].letter < counts[j].letter })
+	return counts
+}
+
+// Excluded returns the rule that removes the word for the guess and feedback, false if the word still matches
+func Excluded(word, guess, feedback WordleWord) (Exclusion, bool) {
+	copies := [26]int{}
+	for _, letter := range word {
+		copies[letter-'a']++
+	}
+	ret := Exclusion{Guess: guess, Feedback: feedback}
+	for i, color := range feedback {
+		if color == 'g' && word[i] != guess[i] {
+			ret.Rule, ret.Letter, ret.Position = RuleGreenMismatch, guess[i], i+1
+			return ret, true
+		}
+	}
+	must, mustNot := MakeLetterMatch2(guess, feedback)
+	for _, letterCount := range sortedLetterCounts(must) {
+		if have := copies[letterCount.letter-'a']; have <= letterCount.count {
+			ret.Rule, ret.Letter, ret.Count, ret.Copies = RuleTooFew, letterCount.letter, letterCount.count+1, have
+			return ret, true
+		}
+	}
+	for _, letterCount := range sortedLetterCounts(mustNot) {
+		if have := copies[letterCount.letter-'a']; have > letterCount.count {
+			ret.Rule, ret.Letter, ret.Count, ret.Copies = RuleTooMany, letterCount.letter, letterCount.count, have
+			if letterCount.count == 0 {
+				ret.Rule = RuleRedPresent
+			}
+			return ret, true
+		}
+	}
+	for i, color := range feedback {
+		if (color == 'y' || color == 'r') && word[i] == guess[i] {
+			ret.Rule, ret.Letter, ret.Position = RuleRedSpot, guess[i], i+1
+			if color == 'y' {
+				ret.Rule = RuleYellowSpot
+			}
+			return ret, true
+		}
+	}
+	return Exclusion{}, false
+}
+
+// ExcludedByHistory returns the first guess in the history that removes the word, false if the word still matches
+func ExcludedByHistory(word WordleWord, history []GuessAnswer) (Exclusion, bool) {
+	if ret := ExclusionsByHistory(word, history); len(ret) > 0 {
+		return ret[0], true
+	}
+	return Exclusion{}, false
+}
+
+// ExclusionsByHistory returns every guess in the history that removes the word, in a history that contradicts
+// itself more than one guess can remove an answer
+func ExclusionsByHistory(word WordleWord, history []GuessAnswer) []Exclusion {
+	ret := []Exclusion{}
+	for i, ga := range history {
+		if exclusion, ok := Excluded(word, ga.Guess, ga.Answer); ok {
+			exclusion.Turn = i + 1
+			ret = append(ret, exclusion)
+		}
+	}
+	return ret
+}
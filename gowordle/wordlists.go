@@ -0,0 +1,79 @@
+package gowordle
+
+/*
+Wordle has two lists: the answers, words that can be the solution, and the guesses, words that are accepted as a guess.
+Every answer is also a guess.  The scorers pick guesses from Guesses and narrow down Answers.
+*/
+
+type WordLists struct {
+	Answers     []WordleWord // words that can be the solution
+	Guesses     []WordleWord // words that can be guessed, includes all of the Answers
+	Hash        string       // HashWords of the Answers and the Guesses
+	GuessesHash string       // contentHash of the Guesses, see GuessList
+	guessSet    map[WordleWord]bool
+}
+
+// NewWordLists pairs the answers with the guesses.  Answers missing from the guesses are added to the end of the
+// guesses, duplicates are dropped and the order is kept.  With no guesses the Guesses are the Answers.
+func NewWordLists(answers_s, guesses_s []string) *WordLists {
+	answers := uniqueWords(StringsToWordleWords(answers_s))
+	guesses := uniqueWords(append(StringsToWordleWords(guesses_s), answers...))
+	ret := &WordLists{Answers: answers, Guesses: guesses, guessSet: make(map[WordleWord]bool, len(guesses))}
+	for _, guess := range guesses {
+		ret.guessSet[guess] = true
+	}
+	ret.Hash = HashWords(append(append(WordleWordsToStrings(answers), ""), WordleWordsToStrings(guesses)...))
+	ret.GuessesHash = contentHash(guesses)
+	return ret
+}
+
+// GuessList is the guesses the solvers pick from with their content hash, the hash is part of the key of every
+// SolverMemo entry and hashing thousands of guesses for every solver call is slow
+type GuessList struct {
+	Words []WordleWord
+	Hash  string // contentHash of the Words
+}
+
+// NewGuessList hashes the words, use the GuessList of a WordLists for its Guesses
+func NewGuessList(words []WordleWord) GuessList {
+	return GuessList{Words: words, Hash: contentHash(words)}
+}
+
+// GuessList is the Guesses with the hash computed by NewWordLists
+func (w *WordLists) GuessList() GuessList {
+	return GuessList{Words: w.Guesses, Hash: w.GuessesHash}
+}
+
+// DefaultWordLists are the first count answers of SortedWordleDictionary, 0 is all, with WordleExtraGuesses
+// added to the guesses
+func DefaultWordLists(count int) *WordLists {
+	return LoadWordLists(SortedWordleDictionary(), count, nil)
+}
+
+// LoadWordLists are the first count answers, 0 is all, the guesses are SortedWordleDictionary, WordleExtraGuesses
+// and the extra guesses
+func LoadWordLists(answers []string, count int, extraGuesses []string) *WordLists {
+	if count > 0 && count < len(answers) {
+		answers = answers[0:count]
+	}
+	guesses := append(append([]string{}, SortedWordleDictionary()...), WordleExtraGuesses...)
+	return NewWordLists(answers, append(guesses, extraGuesses...))
+}
+
+// ValidGuess is true if the guess is in Guesses
+func (wl *WordLists) ValidGuess(guess WordleWord) bool {
+	return wl.guessSet[guess]
+}
+
+// uniqueWords drops the duplicates keeping the first of each word
+func uniqueWords(words []WordleWord) []WordleWord {
+	seen := make(map[WordleWord]bool, len(words))
+	ret := make([]WordleWord, 0, len(words))
+	for _, word := range words {
+		if !seen[word] {
+			seen[word] = true
+			ret = append(ret, word)
+		}
+	}
+	return ret
+}